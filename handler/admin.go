@@ -0,0 +1,337 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	views "github.com/harrydrippin/go-spreadsheet-library/view"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+const adminTokenCookieName = "library_admin_token"
+
+// AdminHandler serves the web console for librarians and admins, who sign in with their API token.
+// Every change goes through LibraryUsecase, so the console follows the same rules as Slack and the API.
+type AdminHandler struct {
+	service     services.LibraryUsecase
+	authService services.AuthUsecase
+}
+
+func NewAdminHandler(service services.LibraryUsecase, authService services.AuthUsecase) *AdminHandler {
+	return &AdminHandler{service: service, authService: authService}
+}
+
+func (h *AdminHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/admin/static/*", echo.WrapHandler(http.StripPrefix("/admin/", http.FileServer(http.FS(views.StaticFS)))))
+	e.GET("/admin/login", h.LoginPage)
+	e.POST("/admin/login", h.Login)
+
+	admin := e.Group("/admin", h.RequireLogin, middleware.CSRFWithConfig(middleware.CSRFConfig{
+		TokenLookup:    "form:_csrf",
+		CookiePath:     "/admin",
+		CookieHTTPOnly: true,
+		CookieSameSite: http.SameSiteStrictMode,
+	}))
+	admin.GET("", h.Catalog)
+	admin.POST("/logout", h.Logout)
+	admin.GET("/books/new", h.NewBook)
+	admin.POST("/books", h.AddBook)
+	admin.GET("/books/:id", h.Book)
+	admin.POST("/books/:id", h.EditBook)
+	admin.POST("/books/:id/due-date", h.ChangeDueDate)
+	admin.POST("/books/:id/borrower", h.Reassign)
+	admin.POST("/books/:id/force-return", h.ForceReturn)
+	admin.POST("/books/:id/status", h.ChangeStatus)
+	admin.POST("/books/:id/archive", h.Archive)
+	admin.GET("/loans", h.Loans)
+	admin.GET("/audit", h.Audit)
+}
+
+// RequireLogin is a middleware that sends anyone but a signed-in librarian or admin to the sign-in page
+func (h *AdminHandler) RequireLogin(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cookie, err := c.Cookie(adminTokenCookieName)
+		if err != nil {
+			return c.Redirect(http.StatusSeeOther, "/admin/login")
+		}
+
+		identity, err := h.authService.Authenticate(cookie.Value)
+		if err != nil || !identity.Role.Includes(models.RoleLibrarian) {
+			h.setTokenCookie(c, "", -1)
+			return c.Redirect(http.StatusSeeOther, "/admin/login")
+		}
+
+		c.Set(identityKey, identity)
+		return next(c)
+	}
+}
+
+func (h *AdminHandler) LoginPage(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return views.RenderAdminLoginPage(c.Response(), views.AdminLoginPage{Locale: requestLocale(c)})
+}
+
+func (h *AdminHandler) Login(c echo.Context) error {
+	locale := requestLocale(c)
+	token := strings.TrimSpace(c.FormValue("token"))
+
+	identity, err := h.authService.Authenticate(token)
+	message := ""
+	switch {
+	case err != nil:
+		message = i18n.Message(locale, err)
+	case !identity.Role.Includes(models.RoleLibrarian):
+		message = i18n.T(locale, "admin.librarian_only")
+	}
+	if message != "" {
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+		c.Response().WriteHeader(http.StatusUnauthorized)
+		return views.RenderAdminLoginPage(c.Response(), views.AdminLoginPage{Locale: locale, Error: message})
+	}
+
+	// 브라우저를 닫으면 로그아웃되도록 만료 시각 없이 저장함
+	h.setTokenCookie(c, token, 0)
+	return c.Redirect(http.StatusSeeOther, "/admin")
+}
+
+func (h *AdminHandler) Logout(c echo.Context) error {
+	h.setTokenCookie(c, "", -1)
+	return c.Redirect(http.StatusSeeOther, "/admin/login")
+}
+
+func (h *AdminHandler) setTokenCookie(c echo.Context, token string, maxAge int) {
+	c.SetCookie(&http.Cookie{
+		Name:     adminTokenCookieName,
+		Value:    token,
+		Path:     "/admin",
+		MaxAge:   maxAge,
+		Secure:   c.Scheme() == "https",
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+// page returns what every page of the console shows, with the result of the last action
+func (h *AdminHandler) page(c echo.Context, message string, err error) views.AdminPage {
+	locale := requestLocale(c)
+	csrf, _ := c.Get(middleware.DefaultCSRFConfig.ContextKey).(string)
+	page := views.AdminPage{Locale: locale, Identity: identityOf(c), CSRF: csrf, Message: message}
+	if err != nil {
+		page.Message = ""
+		page.Error = i18n.Message(locale, err)
+	}
+
+	return page
+}
+
+func (h *AdminHandler) Catalog(c echo.Context) error {
+	ctx := c.Request().Context()
+	query := strings.TrimSpace(c.QueryParam("q"))
+	all := c.QueryParam("all") == "true"
+
+	var books []models.Book
+	var err error
+	switch {
+	case query != "" && all:
+		books, err = h.service.SearchAll(ctx, query)
+	case query != "":
+		books, err = h.service.Search(ctx, query)
+	default:
+		books, err = h.service.List(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	result := []models.Book{}
+	for _, book := range books {
+		if all || book.IsInCirculation() {
+			result = append(result, book)
+		}
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return views.RenderAdminCatalogPage(c.Response(), views.AdminCatalogPage{
+		AdminPage: h.page(c, "", nil),
+		Query:     query,
+		All:       all,
+		Books:     result,
+	})
+}
+
+func (h *AdminHandler) NewBook(c echo.Context) error {
+	return h.renderBookPage(c, models.Book{}, "", nil)
+}
+
+func (h *AdminHandler) AddBook(c echo.Context) error {
+	book, err := h.service.AddBook(c.Request().Context(), formDetails(c), identityOf(c).User)
+	if err != nil {
+		return h.renderBookPage(c, models.Book{}, "", err)
+	}
+
+	return c.Redirect(http.StatusSeeOther, "/admin/books/"+strconv.Itoa(book.ID))
+}
+
+func (h *AdminHandler) Book(c echo.Context) error {
+	book, err := h.findBook(c)
+	if err != nil {
+		return err
+	}
+
+	return h.renderBookPage(c, book, "", nil)
+}
+
+func (h *AdminHandler) EditBook(c echo.Context) error {
+	return h.handleBookAction(c, func(ctx context.Context, book models.Book, user string) (models.Book, error) {
+		return h.service.EditDetails(ctx, book, formDetails(c), user)
+	})
+}
+
+func (h *AdminHandler) ChangeDueDate(c echo.Context) error {
+	return h.handleBookAction(c, func(ctx context.Context, book models.Book, user string) (models.Book, error) {
+		return h.service.ChangeDueDate(ctx, book, c.FormValue("due_date"), user)
+	})
+}
+
+func (h *AdminHandler) Reassign(c echo.Context) error {
+	return h.handleBookAction(c, func(ctx context.Context, book models.Book, user string) (models.Book, error) {
+		borrower := strings.TrimPrefix(strings.TrimSpace(c.FormValue("borrower")), "@")
+		return h.service.Reassign(ctx, book, borrower, user)
+	})
+}
+
+func (h *AdminHandler) ForceReturn(c echo.Context) error {
+	return h.handleBookAction(c, h.service.ForceReturn)
+}
+
+func (h *AdminHandler) ChangeStatus(c echo.Context) error {
+	return h.handleBookAction(c, func(ctx context.Context, book models.Book, user string) (models.Book, error) {
+		status, err := models.ParseStatus(c.FormValue("status"))
+		if err != nil {
+			return models.Book{}, i18n.Wrap(err, "error.unknown_status")
+		}
+
+		return h.service.ChangeStatus(ctx, book, status, user)
+	})
+}
+
+func (h *AdminHandler) Archive(c echo.Context) error {
+	return h.handleBookAction(c, h.service.Archive)
+}
+
+// handleBookAction applies the action to the book as the signed-in user and shows the book again with the result
+func (h *AdminHandler) handleBookAction(c echo.Context, action func(context.Context, models.Book, string) (models.Book, error)) error {
+	book, err := h.findBook(c)
+	if err != nil {
+		return err
+	}
+
+	updated, err := action(c.Request().Context(), book, identityOf(c).User)
+	if err != nil {
+		return h.renderBookPage(c, book, "", err)
+	}
+
+	return h.renderBookPage(c, updated, i18n.T(requestLocale(c), "admin.saved"), nil)
+}
+
+func (h *AdminHandler) renderBookPage(c echo.Context, book models.Book, message string, actionErr error) error {
+	page := views.AdminBookPage{AdminPage: h.page(c, message, actionErr), Book: book}
+	if book.ID != 0 {
+		for _, status := range models.Statuses {
+			if status != book.Status {
+				page.Statuses = append(page.Statuses, status)
+			}
+		}
+
+		entries, err := h.service.AuditTrail(c.Request().Context(), book.ID, identityOf(c).User)
+		if err != nil {
+			return err
+		}
+		page.Entries = newestFirst(entries)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return views.RenderAdminBookPage(c.Response(), page)
+}
+
+// Loans lists the books borrowed now by due date, or only the overdue ones with overdue=true
+func (h *AdminHandler) Loans(c echo.Context) error {
+	books, err := h.service.List(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	overdue := c.QueryParam("overdue") == "true"
+	today := time.Now().Format("2006-01-02")
+	result := []models.Book{}
+	for _, book := range books {
+		if book.Status != models.StatusBorrowed && book.Status != models.StatusOverdue {
+			continue
+		}
+		if overdue && !book.IsOverdue(today) {
+			continue
+		}
+		result = append(result, book)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].DueDate < result[j].DueDate
+	})
+
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return views.RenderAdminLoansPage(c.Response(), views.AdminLoansPage{
+		AdminPage: h.page(c, "", nil),
+		Overdue:   overdue,
+		Today:     today,
+		Books:     result,
+	})
+}
+
+func (h *AdminHandler) Audit(c echo.Context) error {
+	entries, err := h.service.AuditTrail(c.Request().Context(), 0, identityOf(c).User)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return views.RenderAdminAuditPage(c.Response(), views.AdminAuditPage{
+		AdminPage: h.page(c, "", nil),
+		Entries:   newestFirst(entries),
+	})
+}
+
+func (h *AdminHandler) findBook(c echo.Context) (models.Book, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return models.Book{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid book id")
+	}
+
+	return h.service.SearchById(c.Request().Context(), id)
+}
+
+// formDetails reads the book details from the submitted form
+func formDetails(c echo.Context) models.BookDetails {
+	return models.BookDetails{
+		Title:     strings.TrimSpace(c.FormValue("title")),
+		Author:    strings.TrimSpace(c.FormValue("author")),
+		Publisher: strings.TrimSpace(c.FormValue("publisher")),
+		Position:  strings.TrimSpace(c.FormValue("position")),
+	}
+}
+
+// newestFirst returns the audit entries in reverse order, as they are kept oldest first
+func newestFirst(entries []models.AuditEntry) []models.AuditEntry {
+	result := make([]models.AuditEntry, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		result = append(result, entries[i])
+	}
+
+	return result
+}
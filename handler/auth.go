@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	"github.com/labstack/echo/v4"
+)
+
+const identityKey = "identity"
+
+// publicRoutes are the API routes anyone can use without a token, such as the documents opened in a browser
+var publicRoutes = map[string]bool{
+	"/api/openapi.json":     true,
+	"/api/docs":             true,
+	"/api/books/:id/qrcode": true,
+	"/api/labels.pdf":       true,
+	"/api/catalog.pdf":      true,
+}
+
+// AuthHandler authenticates the callers of the API with the bearer token in the Authorization header
+type AuthHandler struct {
+	service services.AuthUsecase
+}
+
+func NewAuthHandler(service services.AuthUsecase) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+func (h *AuthHandler) RegisterRoutes(e *echo.Echo) {
+	e.Use(h.Authenticate)
+	e.GET("/api/me", h.Me)
+}
+
+// Me returns the identity of the caller, which is useful to check a token
+func (h *AuthHandler) Me(c echo.Context) error {
+	return c.JSON(http.StatusOK, identityOf(c))
+}
+
+// Authenticate is a middleware that requires a valid token for the API and GraphQL.
+// Reading needs the read scope and any other method needs the write scope, while GraphQL checks the scope per operation.
+func (h *AuthHandler) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		path := c.Request().URL.Path
+		if !strings.HasPrefix(path, "/api/") && path != graphQLPath || publicRoutes[c.Path()] {
+			return next(c)
+		}
+
+		token := strings.TrimPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+		if token == "" || token == c.Request().Header.Get(echo.HeaderAuthorization) {
+			return i18n.Wrap(models.ErrUnauthenticated, "error.token_required")
+		}
+
+		identity, err := h.service.Authenticate(token)
+		if err != nil {
+			return err
+		}
+
+		scope := models.ScopeWrite
+		if c.Request().Method == http.MethodGet || c.Request().Method == http.MethodHead {
+			scope = models.ScopeRead
+		}
+		if path != graphQLPath && !identity.HasScope(scope) {
+			return i18n.Wrap(models.ErrForbidden, "error.insufficient_scope", scope)
+		}
+
+		c.Set(identityKey, identity)
+		return next(c)
+	}
+}
+
+// requireScope is a middleware for the routes that need a scope besides read or write, such as admin
+func requireScope(scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !identityOf(c).HasScope(scope) {
+				return i18n.Wrap(models.ErrForbidden, "error.insufficient_scope", scope)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// identityOf returns the authenticated caller of the request
+func identityOf(c echo.Context) models.Identity {
+	identity, _ := c.Get(identityKey).(models.Identity)
+	return identity
+}
+
+// actingUser returns the user the caller acts for, which is the caller unless an admin names another user
+func actingUser(c echo.Context, requested string) (string, error) {
+	return actAs(identityOf(c), requested)
+}
+
+func actAs(identity models.Identity, requested string) (string, error) {
+	if requested == "" || requested == identity.User {
+		return identity.User, nil
+	}
+
+	if !identity.HasScope(models.ScopeAdmin) {
+		return "", i18n.Wrap(models.ErrForbidden, "error.act_for_others")
+	}
+
+	return requested, nil
+}
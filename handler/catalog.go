@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	views "github.com/harrydrippin/go-spreadsheet-library/view"
+	"github.com/labstack/echo/v4"
+)
+
+// Values of the availability filter of the catalog
+const (
+	catalogAvailable = "available"
+	catalogBorrowed  = "borrowed"
+)
+
+// CatalogHandler serves the public, read-only catalog for people outside Slack
+type CatalogHandler struct {
+	service       services.LibraryUsecase
+	reviewService services.ReviewUsecase
+}
+
+func NewCatalogHandler(service services.LibraryUsecase, reviewService services.ReviewUsecase) *CatalogHandler {
+	return &CatalogHandler{service: service, reviewService: reviewService}
+}
+
+func (h *CatalogHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/catalog", h.Catalog)
+	e.GET("/catalog/:id", h.Book)
+}
+
+// Catalog lists the books in circulation matching the title, filtered by availability and position
+func (h *CatalogHandler) Catalog(c echo.Context) error {
+	ctx := c.Request().Context()
+	page := views.CatalogPage{
+		Locale:   requestLocale(c),
+		Query:    strings.TrimSpace(c.QueryParam("q")),
+		Status:   c.QueryParam("status"),
+		Position: c.QueryParam("position"),
+	}
+	if page.Status != "" && page.Status != catalogAvailable && page.Status != catalogBorrowed {
+		return echo.NewHTTPError(http.StatusBadRequest, "Status must be available or borrowed")
+	}
+
+	books, err := h.service.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 위치 목록은 검색어와 관계없이 전체 책에서 만듦
+	positions := make(map[string]bool)
+	for _, book := range books {
+		if book.IsInCirculation() && book.Position != "" && !positions[book.Position] {
+			positions[book.Position] = true
+			page.Positions = append(page.Positions, book.Position)
+		}
+	}
+	sort.Strings(page.Positions)
+
+	if page.Query != "" {
+		books, err = h.service.Search(ctx, page.Query)
+		if err != nil {
+			return err
+		}
+	}
+
+	page.Books = []models.Book{}
+	ids := []int{}
+	for _, book := range books {
+		if !book.IsInCirculation() {
+			continue
+		}
+		if page.Status == catalogAvailable && !book.IsAvailable() || page.Status == catalogBorrowed && book.IsAvailable() {
+			continue
+		}
+		if page.Position != "" && book.Position != page.Position {
+			continue
+		}
+		page.Books = append(page.Books, book)
+		ids = append(ids, book.ID)
+	}
+
+	page.Summaries, err = h.reviewService.Summaries(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return views.RenderCatalogPage(c.Response(), page)
+}
+
+// Book shows a book in circulation with its availability and ratings
+func (h *CatalogHandler) Book(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid book id")
+	}
+
+	book, err := h.service.SearchById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// 폐기되거나 분실된 책은 목록에 없으므로 찾을 수 없는 것으로 처리함
+	if !book.IsInCirculation() {
+		return i18n.Wrap(models.ErrNotFound, "error.book_not_found", book.ID)
+	}
+
+	summary, err := h.reviewService.Summary(ctx, book.ID)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return views.RenderCatalogBookPage(c.Response(), views.CatalogBookPage{Locale: requestLocale(c), Book: book, Summary: summary})
+}
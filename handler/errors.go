@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	"github.com/harrydrippin/go-spreadsheet-library/logging"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	"github.com/labstack/echo/v4"
+)
+
+// errorStatuses maps each kind of domain error to its HTTP status
+var errorStatuses = map[*models.DomainError]int{
+	models.ErrAlreadyBorrowed: http.StatusConflict,
+	models.ErrNotBorrowed:     http.StatusConflict,
+	models.ErrNotBorrower:     http.StatusForbidden,
+	models.ErrNotFound:        http.StatusNotFound,
+	models.ErrUnauthenticated: http.StatusUnauthorized,
+	models.ErrConflict:        http.StatusConflict,
+	models.ErrForbidden:       http.StatusForbidden,
+	models.ErrInvalidInput:    http.StatusBadRequest,
+	models.ErrUnavailable:     http.StatusServiceUnavailable,
+}
+
+// ErrorResponse is the JSON body of every API error.
+// Code is stable and meant for programs, while Message is meant for people and follows Accept-Language.
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// HandleError is the HTTP error handler of the server.
+// Domain errors are mapped to their HTTP status and code, and any other error is an internal error.
+func HandleError(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	logger := logging.FromContext(c.Request().Context())
+	status, response := errorResponse(err, requestLocale(c))
+	if status >= http.StatusInternalServerError {
+		logger.WithError(err).Error("Request failed")
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else {
+		err = c.JSON(status, response)
+	}
+	if err != nil {
+		logger.WithError(err).Error("Unable to send the error response")
+	}
+}
+
+func errorResponse(err error, locale string) (int, ErrorResponse) {
+	var httpError *echo.HTTPError
+	if errors.As(err, &httpError) {
+		return httpError.Code, ErrorResponse{
+			Code:    strings.ReplaceAll(strings.ToLower(http.StatusText(httpError.Code)), " ", "_"),
+			Message: fmt.Sprint(httpError.Message),
+		}
+	}
+
+	var domainError *models.DomainError
+	if errors.As(err, &domainError) {
+		status := errorStatuses[domainError]
+		message := i18n.Message(locale, err)
+		if status >= http.StatusInternalServerError {
+			// 저장소 오류의 자세한 내용은 로그에만 남김
+			message = i18n.T(locale, "error.server")
+		}
+
+		return status, ErrorResponse{Code: domainError.Code, Message: message}
+	}
+
+	return http.StatusInternalServerError, ErrorResponse{
+		Code:    "internal_error",
+		Message: i18n.T(locale, "error.server"),
+	}
+}
@@ -0,0 +1,538 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// graphQLPath is authenticated like the API, but the scope is checked per operation instead of per HTTP method
+const graphQLPath = "/graphql"
+
+// graphQLListSize is the number of items a list is assumed to hold when estimating the cost of a query
+const graphQLListSize = 20
+
+// GraphQLHandler serves books, loans and user summaries through GraphQL, so that a dashboard can fetch them in one round trip
+type GraphQLHandler struct {
+	service       services.LibraryUsecase
+	schema        graphql.Schema
+	maxComplexity int
+	maxDepth      int
+}
+
+// graphQLParams is a GraphQL request, sent as the JSON body of a POST or as the query string of a GET
+type graphQLParams struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// graphQLUser is a user as seen by the resolvers. Only the name is known until a field asks for more.
+type graphQLUser struct {
+	Name string
+}
+
+type graphQLContextKey struct{}
+
+// graphQLRequest is shared by the resolvers of a request: the caller, and every book read at most once
+type graphQLRequest struct {
+	c     echo.Context
+	once  sync.Once
+	books map[int]models.Book
+	err   error
+}
+
+// graphQLError carries the code of a domain error to the extensions of the GraphQL error
+type graphQLError struct {
+	ErrorResponse
+}
+
+func (e graphQLError) Error() string {
+	return e.Message
+}
+
+func (e graphQLError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.Code}
+}
+
+func NewGraphQLHandler(service services.LibraryUsecase, config utils.Config) *GraphQLHandler {
+	h := &GraphQLHandler{
+		service:       service,
+		maxComplexity: config.GraphQLMaxComplexity,
+		maxDepth:      config.GraphQLMaxDepth,
+	}
+
+	schema, err := h.newSchema()
+	if err != nil {
+		panic(err)
+	}
+	h.schema = schema
+
+	return h
+}
+
+func (h *GraphQLHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET(graphQLPath, h.Query)
+	e.POST(graphQLPath, h.Query)
+}
+
+func (h *GraphQLHandler) newSchema() (graphql.Schema, error) {
+	bookType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Book",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"title":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"author":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"publisher": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"position":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"status":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"borrower":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"dueDate": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(models.Book).DueDate, nil
+				},
+			},
+			"available": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Whether the book can be borrowed right now",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(models.Book).IsAvailable(), nil
+				},
+			},
+		},
+	})
+
+	loanType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Loan",
+		Fields: graphql.Fields{
+			"bookId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(models.Loan).BookID, nil
+				},
+			},
+			"book": &graphql.Field{
+				Type:        bookType,
+				Description: "The borrowed book, or null if it was deleted",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return h.requestOf(p.Context).book(p.Context, h.service, p.Source.(models.Loan).BookID)
+				},
+			},
+			"borrower": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"borrowedAt": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(models.Loan).BorrowedAt, nil
+				},
+			},
+			"returnedAt": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if p.Source.(models.Loan).IsOpen() {
+						return nil, nil
+					}
+					return p.Source.(models.Loan).ReturnedAt, nil
+				},
+			},
+			"open": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(models.Loan).IsOpen(), nil
+				},
+			},
+		},
+	})
+
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(graphQLUser).Name, nil
+				},
+			},
+			"borrowing": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(bookType))),
+				Description: "The books the user has now, including lost ones",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return h.service.Status(p.Context, p.Source.(graphQLUser).Name)
+				},
+			},
+			"loans": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(loanType))),
+				Description: "The loan history of the user, or only the books not returned yet with open: true",
+				Args: graphql.FieldConfigArgument{
+					"open": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					loans, err := h.service.Loans(p.Context, p.Source.(graphQLUser).Name)
+					if err != nil || !p.Args["open"].(bool) {
+						return loans, err
+					}
+
+					open := []models.Loan{}
+					for _, loan := range loans {
+						if loan.IsOpen() {
+							open = append(open, loan)
+						}
+					}
+					return open, nil
+				},
+			},
+			"loanCount": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					loans, err := h.service.Loans(p.Context, p.Source.(graphQLUser).Name)
+					return len(loans), err
+				},
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"books": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(bookType))),
+				Description: "The books in circulation, optionally filtered by title and status. Books out of circulation are included with all: true.",
+				Args: graphql.FieldConfigArgument{
+					"title":  &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: ""},
+					"status": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: ""},
+					"all":    &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return h.books(p.Context, p.Args["title"].(string), p.Args["status"].(string), p.Args["all"].(bool))
+				},
+			},
+			"book": &graphql.Field{
+				Type: bookType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					book, err := h.service.SearchById(p.Context, p.Args["id"].(int))
+					if err != nil {
+						return nil, err
+					}
+					return book, nil
+				},
+			},
+			"user": &graphql.Field{
+				Type:        graphql.NewNonNull(userType),
+				Description: "The caller, or another user for librarians and admins",
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: ""},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					user, err := actingUser(h.requestOf(p.Context).c, p.Args["name"].(string))
+					return graphQLUser{Name: user}, err
+				},
+			},
+			"me": &graphql.Field{
+				Type: graphql.NewNonNull(userType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return graphQLUser{Name: identityOf(h.requestOf(p.Context).c).User}, nil
+				},
+			},
+		},
+	})
+
+	mutationType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"borrow": h.loanMutation(bookType, h.service.Borrow),
+			"return": h.loanMutation(bookType, h.service.Return),
+			"extend": h.loanMutation(bookType, h.service.Extend),
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType, Mutation: mutationType})
+}
+
+// loanMutation applies the action to the book for the caller, or for the borrower they name if the caller has the admin scope
+func (h *GraphQLHandler) loanMutation(bookType *graphql.Object, action func(context.Context, models.Book, string) (models.Book, error)) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewNonNull(bookType),
+		Args: graphql.FieldConfigArgument{
+			"id":       &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"borrower": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: ""},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			borrower, err := actingUser(h.requestOf(p.Context).c, p.Args["borrower"].(string))
+			if err != nil {
+				return nil, err
+			}
+
+			book, err := h.service.SearchById(p.Context, p.Args["id"].(int))
+			if err != nil {
+				return nil, err
+			}
+
+			book, err = action(p.Context, book, borrower)
+			if err != nil {
+				return nil, err
+			}
+			return book, nil
+		},
+	}
+}
+
+// books returns the books like the list of the v2 API
+func (h *GraphQLHandler) books(ctx context.Context, title string, status string, all bool) ([]models.Book, error) {
+	var books []models.Book
+	var err error
+	switch {
+	case title != "" && all:
+		books, err = h.service.SearchAll(ctx, title)
+	case title != "":
+		books, err = h.service.Search(ctx, title)
+	default:
+		books, err = h.service.List(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var wanted models.Status
+	if status != "" {
+		wanted, err = models.ParseStatus(status)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := []models.Book{}
+	for _, book := range books {
+		if !all && !book.IsInCirculation() {
+			continue
+		}
+		if wanted != "" && book.Status != wanted {
+			continue
+		}
+		result = append(result, book)
+	}
+
+	return result, nil
+}
+
+// Query runs a GraphQL query or mutation. Queries need the read scope and mutations need the write scope.
+// Queries costing more than the limit are rejected before they run.
+func (h *GraphQLHandler) Query(c echo.Context) error {
+	params := graphQLParams{}
+	if c.Request().Method == http.MethodGet {
+		params.Query = c.QueryParam("query")
+		params.OperationName = c.QueryParam("operationName")
+		if c.QueryParam("variables") != "" {
+			if err := json.Unmarshal([]byte(c.QueryParam("variables")), &params.Variables); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+		}
+	} else if err := json.NewDecoder(c.Request().Body).Decode(&params); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	document, err := parser.Parse(parser.ParseParams{Source: params.Query})
+	if err != nil {
+		return c.JSON(http.StatusOK, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+	}
+
+	validation := graphql.ValidateDocument(&h.schema, document, nil)
+	if !validation.IsValid {
+		return c.JSON(http.StatusOK, &graphql.Result{Errors: validation.Errors})
+	}
+
+	// 실행할 작업을 알 수 없으면 권한과 비용을 확인할 수 없으므로 실행하지 않음
+	operation := findOperation(document, params.OperationName)
+	if operation == nil {
+		if params.OperationName != "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "Unknown operation: "+params.OperationName)
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, "operationName is required when the document has several operations")
+	}
+
+	scope := models.ScopeRead
+	if operation.Operation == ast.OperationTypeMutation {
+		scope = models.ScopeWrite
+	}
+	// 변경은 GET으로 요청할 수 없음
+	if scope == models.ScopeWrite && c.Request().Method == http.MethodGet {
+		return echo.NewHTTPError(http.StatusMethodNotAllowed, "Mutations must be sent with POST")
+	}
+	if !identityOf(c).HasScope(scope) {
+		return i18n.Wrap(models.ErrForbidden, "error.insufficient_scope", scope)
+	}
+
+	if err := h.checkCost(document, operation); err != nil {
+		_, response := errorResponse(err, requestLocale(c))
+		formatted := gqlerrors.FormatError(graphQLError{response})
+		formatted.Extensions = graphQLError{response}.Extensions()
+		return c.JSON(http.StatusOK, &graphql.Result{Errors: []gqlerrors.FormattedError{formatted}})
+	}
+
+	ctx := context.WithValue(c.Request().Context(), graphQLContextKey{}, &graphQLRequest{c: c})
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           document,
+		OperationName: params.OperationName,
+		Args:          params.Variables,
+		Context:       ctx,
+	})
+
+	// 도메인 오류는 REST API와 같은 코드와 사용자의 언어로 된 메시지로 알려줌
+	for i, formatted := range result.Errors {
+		located, ok := formatted.OriginalError().(*gqlerrors.Error)
+		if !ok || located.OriginalError == nil {
+			continue
+		}
+		_, response := errorResponse(located.OriginalError, requestLocale(c))
+		result.Errors[i].Message = response.Message
+		result.Errors[i].Extensions = graphQLError{response}.Extensions()
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *GraphQLHandler) requestOf(ctx context.Context) *graphQLRequest {
+	return ctx.Value(graphQLContextKey{}).(*graphQLRequest)
+}
+
+// book returns the book with the ID, or nil if it was deleted. Every book is read once per request.
+func (r *graphQLRequest) book(ctx context.Context, service services.LibraryUsecase, id int) (interface{}, error) {
+	r.once.Do(func() {
+		var books []models.Book
+		books, r.err = service.List(ctx)
+		r.books = make(map[int]models.Book)
+		for _, book := range books {
+			r.books[book.ID] = book
+		}
+	})
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if book, ok := r.books[id]; ok {
+		return book, nil
+	}
+	return nil, nil
+}
+
+// checkCost rejects the operation if it is deeper or costs more than the limits
+func (h *GraphQLHandler) checkCost(document *ast.Document, operation *ast.OperationDefinition) error {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	root := h.schema.QueryType()
+	if operation.Operation == ast.OperationTypeMutation {
+		root = h.schema.MutationType()
+	}
+
+	cost, depth := queryCost(root, operation.SelectionSet, fragments)
+	if h.maxDepth > 0 && depth > h.maxDepth {
+		return i18n.Wrap(models.ErrInvalidInput, "error.query_too_deep", depth, h.maxDepth)
+	}
+	if h.maxComplexity > 0 && cost > h.maxComplexity {
+		return i18n.Wrap(models.ErrInvalidInput, "error.query_too_complex", cost, h.maxComplexity)
+	}
+
+	return nil
+}
+
+// queryCost returns the cost and the depth of the selections on the parent type.
+// Every field costs 1, and the fields under a list count once per item.
+// The document must be validated, so that fragments do not refer to each other in a cycle.
+func queryCost(parent graphql.Type, selections *ast.SelectionSet, fragments map[string]*ast.FragmentDefinition) (int, int) {
+	object, ok := parent.(*graphql.Object)
+	if !ok || selections == nil {
+		return 0, 0
+	}
+
+	cost, depth := 0, 0
+	for _, selection := range selections.Selections {
+		var childCost, childDepth int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			field, ok := object.Fields()[selection.Name.Value]
+			if !ok {
+				// __typename 등 스키마 정보는 비용에 포함하지 않음
+				continue
+			}
+
+			fieldType, isList := unwrapType(field.Type)
+			childCost, childDepth = queryCost(fieldType, selection.SelectionSet, fragments)
+			if isList {
+				childCost *= graphQLListSize
+			}
+			childCost++
+			childDepth++
+		case *ast.InlineFragment:
+			childCost, childDepth = queryCost(parent, selection.SelectionSet, fragments)
+		case *ast.FragmentSpread:
+			if fragment, ok := fragments[selection.Name.Value]; ok {
+				childCost, childDepth = queryCost(parent, fragment.SelectionSet, fragments)
+			}
+		}
+
+		cost += childCost
+		if childDepth > depth {
+			depth = childDepth
+		}
+	}
+
+	return cost, depth
+}
+
+// unwrapType strips the non-null and list wrappers off the type, reporting whether it was a list
+func unwrapType(fieldType graphql.Type) (graphql.Type, bool) {
+	isList := false
+	for {
+		switch wrapper := fieldType.(type) {
+		case *graphql.NonNull:
+			fieldType = wrapper.OfType
+		case *graphql.List:
+			isList = true
+			fieldType = wrapper.OfType
+		default:
+			return fieldType, isList
+		}
+	}
+}
+
+// findOperation returns the operation to run: the one with the name, or the only one in the document.
+// It returns nil if there is no operation with the name, or several operations and no name.
+func findOperation(document *ast.Document, name string) *ast.OperationDefinition {
+	var found *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		if name == "" {
+			if found != nil {
+				return nil
+			}
+			found = operation
+		} else if operation.Name != nil && operation.Name.Value == name {
+			return operation
+		}
+	}
+
+	return found
+}
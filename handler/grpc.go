@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	"github.com/harrydrippin/go-spreadsheet-library/logging"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	"github.com/harrydrippin/go-spreadsheet-library/rpc"
+	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// grpcScopes is the scope each method of the library service needs.
+// A method left out of the map needs the admin scope, so that a new method is never public by mistake.
+var grpcScopes = map[string]string{
+	"/library.v1.Library/Search":  models.ScopeRead,
+	"/library.v1.Library/GetBook": models.ScopeRead,
+	"/library.v1.Library/Status":  models.ScopeRead,
+	"/library.v1.Library/Borrow":  models.ScopeWrite,
+	"/library.v1.Library/Return":  models.ScopeWrite,
+	"/library.v1.Library/Extend":  models.ScopeWrite,
+}
+
+// grpcPublicServices are the services open to anyone, which orchestrators and tools such as grpcurl call without a token
+var grpcPublicServices = map[string]bool{
+	healthpb.Health_ServiceDesc.ServiceName:    true,
+	"grpc.reflection.v1alpha.ServerReflection": true,
+}
+
+// grpcCodes maps each kind of domain error to its gRPC status code
+var grpcCodes = map[*models.DomainError]codes.Code{
+	models.ErrAlreadyBorrowed: codes.FailedPrecondition,
+	models.ErrNotBorrowed:     codes.FailedPrecondition,
+	models.ErrNotBorrower:     codes.PermissionDenied,
+	models.ErrNotFound:        codes.NotFound,
+	models.ErrUnauthenticated: codes.Unauthenticated,
+	models.ErrConflict:        codes.Aborted,
+	models.ErrForbidden:       codes.PermissionDenied,
+	models.ErrInvalidInput:    codes.InvalidArgument,
+	models.ErrUnavailable:     codes.Unavailable,
+}
+
+// bookStatuses maps each book status to its protobuf enum
+var bookStatuses = map[models.Status]rpc.BookStatus{
+	models.StatusInOffice:  rpc.BookStatus_BOOK_STATUS_IN_OFFICE,
+	models.StatusBorrowed:  rpc.BookStatus_BOOK_STATUS_BORROWED,
+	models.StatusOverdue:   rpc.BookStatus_BOOK_STATUS_OVERDUE,
+	models.StatusLost:      rpc.BookStatus_BOOK_STATUS_LOST,
+	models.StatusDamaged:   rpc.BookStatus_BOOK_STATUS_DAMAGED,
+	models.StatusWithdrawn: rpc.BookStatus_BOOK_STATUS_WITHDRAWN,
+	models.StatusRepairing: rpc.BookStatus_BOOK_STATUS_REPAIRING,
+}
+
+type grpcIdentityKey struct{}
+
+// GRPCServer serves the library service over gRPC for other backends, next to the REST API.
+// Callers are authenticated with the same API tokens, sent in the "authorization" metadata.
+type GRPCServer struct {
+	rpc.UnimplementedLibraryServer
+	service     services.LibraryUsecase
+	authService services.AuthUsecase
+}
+
+func NewGRPCServer(service services.LibraryUsecase, authService services.AuthUsecase) *GRPCServer {
+	return &GRPCServer{service: service, authService: authService}
+}
+
+// Serve serves the library service with the health and reflection services on the listener
+func (s *GRPCServer) Serve(listener net.Listener) error {
+	server := grpc.NewServer(grpc.UnaryInterceptor(s.intercept), grpc.StreamInterceptor(interceptStream))
+	rpc.RegisterLibraryServer(server, s)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(rpc.Library_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	return server.Serve(listener)
+}
+
+// intercept authenticates the calls to the library service and turns their errors into gRPC statuses.
+// Like HTTP requests, every call gets a request ID from the "x-request-id" metadata or a new one, and is logged.
+// The health and reflection services are open to anyone.
+func (s *GRPCServer) intercept(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if isPublicMethod(info.FullMethod) {
+		return handler(ctx, request)
+	}
+
+	start := time.Now()
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := logging.NewRequestID()
+	if values := md.Get("x-request-id"); len(values) > 0 && values[0] != "" && len(values[0]) <= maxRequestIDLength {
+		id = values[0]
+	}
+	ctx = logging.WithRequestID(ctx, id)
+	grpc.SetHeader(ctx, metadata.Pairs("x-request-id", id))
+
+	locale := i18n.DefaultLocale
+	if values := md.Get("accept-language"); len(values) > 0 {
+		locale = i18n.FromAcceptLanguage(values[0])
+	}
+
+	scope, ok := grpcScopes[info.FullMethod]
+	if !ok {
+		logging.FromContext(ctx).WithField("method", info.FullMethod).Warn("gRPC method has no scope, requiring admin")
+		scope = models.ScopeAdmin
+	}
+
+	response, err := s.call(ctx, md, scope, request, handler)
+	if err != nil {
+		err = grpcError(ctx, err, locale)
+	}
+
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		"method":   info.FullMethod,
+		"code":     status.Code(err).String(),
+		"duration": time.Since(start).Seconds(),
+	}).Info("gRPC call")
+	return response, err
+}
+
+// interceptStream lets the streams of the public services through, such as reflection.
+// The library service has no streaming method, and one would need authentication before being allowed here.
+func interceptStream(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !isPublicMethod(info.FullMethod) {
+		return status.Error(codes.PermissionDenied, "streaming calls are not allowed")
+	}
+
+	return handler(server, stream)
+}
+
+// isPublicMethod returns whether the method, named "/service/method", belongs to a public service
+func isPublicMethod(method string) bool {
+	service := strings.SplitN(strings.TrimPrefix(method, "/"), "/", 2)[0]
+	return grpcPublicServices[service]
+}
+
+func (s *GRPCServer) call(ctx context.Context, md metadata.MD, scope string, request interface{}, handler grpc.UnaryHandler) (interface{}, error) {
+	identity, err := s.authenticate(md, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(context.WithValue(ctx, grpcIdentityKey{}, identity), request)
+}
+
+func (s *GRPCServer) authenticate(md metadata.MD, scope string) (models.Identity, error) {
+	values := md.Get("authorization")
+	if len(values) == 0 || !strings.HasPrefix(values[0], "Bearer ") {
+		return models.Identity{}, i18n.Wrap(models.ErrUnauthenticated, "error.token_required")
+	}
+
+	identity, err := s.authService.Authenticate(strings.TrimPrefix(values[0], "Bearer "))
+	if err != nil {
+		return models.Identity{}, err
+	}
+
+	if !identity.HasScope(scope) {
+		return models.Identity{}, i18n.Wrap(models.ErrForbidden, "error.insufficient_scope", scope)
+	}
+
+	return identity, nil
+}
+
+func (s *GRPCServer) Search(ctx context.Context, request *rpc.SearchRequest) (*rpc.SearchResponse, error) {
+	var books []models.Book
+	var err error
+	switch {
+	case request.Title != "" && request.All:
+		books, err = s.service.SearchAll(ctx, request.Title)
+	case request.Title != "":
+		books, err = s.service.Search(ctx, request.Title)
+	default:
+		books, err = s.service.List(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &rpc.SearchResponse{Books: []*rpc.Book{}}
+	for _, book := range books {
+		if request.All || book.IsInCirculation() {
+			response.Books = append(response.Books, toRPCBook(book))
+		}
+	}
+
+	return response, nil
+}
+
+func (s *GRPCServer) GetBook(ctx context.Context, request *rpc.GetBookRequest) (*rpc.Book, error) {
+	book, err := s.service.SearchById(ctx, int(request.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	return toRPCBook(book), nil
+}
+
+func (s *GRPCServer) Borrow(ctx context.Context, request *rpc.LoanRequest) (*rpc.Book, error) {
+	return s.loanAction(ctx, request, s.service.Borrow)
+}
+
+func (s *GRPCServer) Return(ctx context.Context, request *rpc.LoanRequest) (*rpc.Book, error) {
+	return s.loanAction(ctx, request, s.service.Return)
+}
+
+func (s *GRPCServer) Extend(ctx context.Context, request *rpc.LoanRequest) (*rpc.Book, error) {
+	return s.loanAction(ctx, request, s.service.Extend)
+}
+
+func (s *GRPCServer) Status(ctx context.Context, request *rpc.StatusRequest) (*rpc.StatusResponse, error) {
+	borrower, err := actAs(grpcIdentityOf(ctx), request.Borrower)
+	if err != nil {
+		return nil, err
+	}
+
+	books, err := s.service.Status(ctx, borrower)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &rpc.StatusResponse{Books: []*rpc.Book{}}
+	for _, book := range books {
+		response.Books = append(response.Books, toRPCBook(book))
+	}
+
+	return response, nil
+}
+
+// loanAction finds the book and applies the action for the caller, or for the borrower they name if the caller has the admin scope
+func (s *GRPCServer) loanAction(ctx context.Context, request *rpc.LoanRequest, action func(context.Context, models.Book, string) (models.Book, error)) (*rpc.Book, error) {
+	borrower, err := actAs(grpcIdentityOf(ctx), request.Borrower)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := s.service.SearchById(ctx, int(request.BookId))
+	if err != nil {
+		return nil, err
+	}
+
+	book, err = action(ctx, book, borrower)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRPCBook(book), nil
+}
+
+func grpcIdentityOf(ctx context.Context) models.Identity {
+	identity, _ := ctx.Value(grpcIdentityKey{}).(models.Identity)
+	return identity
+}
+
+func toRPCBook(book models.Book) *rpc.Book {
+	return &rpc.Book{
+		Id:        int32(book.ID),
+		Title:     book.Title,
+		Author:    book.Author,
+		Publisher: book.Publisher,
+		Position:  book.Position,
+		Status:    bookStatuses[book.Status],
+		Borrower:  book.Borrower,
+		DueDate:   book.DueDate,
+	}
+}
+
+// grpcError turns the error into a gRPC status with the same message as the REST API
+func grpcError(ctx context.Context, err error, locale string) error {
+	_, response := errorResponse(err, locale)
+
+	code := codes.Internal
+	var domainError *models.DomainError
+	if errors.As(err, &domainError) {
+		if mapped, ok := grpcCodes[domainError]; ok {
+			code = mapped
+		}
+	}
+
+	// 저장소 오류의 자세한 내용은 로그에만 남김
+	if code == codes.Internal || code == codes.Unavailable {
+		logging.FromContext(ctx).WithError(err).Error("gRPC call failed")
+	}
+
+	return status.Error(code, response.Message)
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/slack-go/slack"
+)
+
+// HealthHandler serves the probes of the orchestrator: /healthz tells that the process is alive,
+// and /readyz that the dependencies it needs to serve requests are healthy
+type HealthHandler struct {
+	service services.HealthUsecase
+}
+
+func NewHealthHandler(service services.HealthUsecase) *HealthHandler {
+	return &HealthHandler{service: service}
+}
+
+func (h *HealthHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/healthz", h.Liveness)
+	e.GET("/readyz", h.Readiness)
+}
+
+// Liveness answers as long as the server can handle a request, without checking any dependency,
+// so that the pod is not restarted because Google or Slack is down
+func (h *HealthHandler) Liveness(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Readiness returns the health of each dependency, with 503 if any of them is unavailable
+func (h *HealthHandler) Readiness(c echo.Context) error {
+	readiness := h.service.Readiness(c.Request().Context())
+	if !readiness.Ready() {
+		return c.JSON(http.StatusServiceUnavailable, readiness)
+	}
+
+	return c.JSON(http.StatusOK, readiness)
+}
+
+// NewSlackAuthCheck returns a check that fails if Slack does not accept the token, such as when the app was uninstalled
+func NewSlackAuthCheck(config utils.Config) services.HealthCheck {
+	client := slack.New(config.SlackToken)
+
+	return func(ctx context.Context) error {
+		_, err := client.AuthTestContext(ctx)
+		return err
+	}
+}
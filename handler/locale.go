@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"context"
+	"sync"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	"github.com/harrydrippin/go-spreadsheet-library/logging"
+	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/slack-go/slack"
+)
+
+// SlackLocaleResolver picks the locale of a Slack user.
+// The language the user chose with the language command comes first, then the locale of their Slack account.
+type SlackLocaleResolver struct {
+	client      *slack.Client
+	preferences services.PreferenceUsecase
+
+	mutex   sync.Mutex
+	locales map[string]string
+}
+
+func NewSlackLocaleResolver(preferences services.PreferenceUsecase, config utils.Config) *SlackLocaleResolver {
+	return &SlackLocaleResolver{
+		client:      slack.New(config.SlackToken),
+		preferences: preferences,
+		locales:     make(map[string]string),
+	}
+}
+
+// Locale returns the locale for the Slack user with the given ID and name
+func (r *SlackLocaleResolver) Locale(ctx context.Context, userId string, userName string) string {
+	if locale, ok := r.preferences.Locale(ctx, userName); ok {
+		return locale
+	}
+
+	r.mutex.Lock()
+	locale, ok := r.locales[userId]
+	r.mutex.Unlock()
+	if ok {
+		return locale
+	}
+
+	// 느린 Slack 호출이 다른 사용자의 조회를 막지 않도록 잠금을 풀고 조회함
+	user, err := r.client.GetUserInfoContext(ctx, userId)
+	if err != nil {
+		// 다음 요청에서 다시 조회할 수 있도록 캐시하지 않음
+		logging.FromContext(ctx).WithError(err).WithField("user_id", userId).Warn("Unable to read the locale of the Slack user")
+		return i18n.DefaultLocale
+	}
+
+	locale = i18n.Normalize(user.Locale)
+	r.mutex.Lock()
+	r.locales[userId] = locale
+	r.mutex.Unlock()
+	return locale
+}
+
+// requestLocale returns the locale requested by the Accept-Language header
+func requestLocale(c echo.Context) string {
+	return i18n.FromAcceptLanguage(c.Request().Header.Get("Accept-Language"))
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/harrydrippin/go-spreadsheet-library/logging"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// maxRequestIDLength is the longest request ID taken from a client, so that a log line cannot be flooded
+const maxRequestIDLength = 64
+
+// RequestLogger gives every request an ID and logs the request when it is done.
+// The ID comes from the X-Request-ID header if a proxy set one, is sent back in the response,
+// and is carried by the request context to every log of the services and repositories.
+func RequestLogger(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+
+		request := c.Request()
+		id := request.Header.Get(echo.HeaderXRequestID)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = logging.NewRequestID()
+		}
+		c.SetRequest(request.WithContext(logging.WithRequestID(request.Context(), id)))
+		c.Response().Header().Set(echo.HeaderXRequestID, id)
+
+		// 응답의 상태 코드를 남길 수 있도록 오류 처리를 먼저 실행함
+		if err := next(c); err != nil {
+			c.Error(err)
+		}
+
+		logging.FromContext(c.Request().Context()).WithFields(logrus.Fields{
+			"method":    request.Method,
+			"path":      request.URL.Path,
+			"route":     c.Path(),
+			"status":    c.Response().Status,
+			"duration":  time.Since(start).Seconds(),
+			"remote_ip": c.RealIP(),
+		}).Info("Request")
+		return nil
+	}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/harrydrippin/go-spreadsheet-library/metrics"
+	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MetricsHandler serves the Prometheus metrics and keeps the gauges of the books up to date
+type MetricsHandler struct {
+	service services.LibraryUsecase
+
+	once   sync.Once
+	routes map[string]bool
+}
+
+func NewMetricsHandler(service services.LibraryUsecase) *MetricsHandler {
+	return &MetricsHandler{service: service}
+}
+
+// RegisterRoutes serves /metrics and measures every HTTP request, including the ones to /metrics
+func (h *MetricsHandler) RegisterRoutes(e *echo.Echo) {
+	e.Use(h.Measure)
+	e.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
+}
+
+// Measure counts the request and observes its latency by the echo route, so that paths with IDs share their labels
+func (h *MetricsHandler) Measure(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+
+		// 오류 응답의 상태 코드를 알 수 있도록 오류 처리를 먼저 실행함
+		if err := next(c); err != nil {
+			c.Error(err)
+		}
+
+		route := h.route(c)
+		method := c.Request().Method
+		metrics.HTTPRequests.WithLabelValues(method, route, strconv.Itoa(c.Response().Status)).Inc()
+		metrics.HTTPDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+		return nil
+	}
+}
+
+// route returns the echo route of the request. Echo gives the raw path of a request that matches no route,
+// which would add a label for every path someone tries, so it is replaced with unmatched.
+func (h *MetricsHandler) route(c echo.Context) string {
+	// 라우트는 서버를 시작할 때 모두 등록되므로 첫 요청에서 한 번만 모음
+	h.once.Do(func() {
+		h.routes = make(map[string]bool)
+		for _, route := range c.Echo().Routes() {
+			h.routes[route.Path] = true
+		}
+	})
+
+	if !h.routes[c.Path()] {
+		return metrics.Unmatched
+	}
+
+	return c.Path()
+}
+
+// Refresh counts the books that are available, borrowed or overdue.
+// It reads every book from the sheet, so it is run periodically instead of on every scrape.
+func (h *MetricsHandler) Refresh(ctx context.Context) error {
+	books, err := h.service.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	today := time.Now().Format("2006-01-02")
+	counts := map[string]int{"available": 0, "borrowed": 0, "overdue": 0}
+	for _, book := range books {
+		switch {
+		case book.IsAvailable():
+			counts["available"]++
+		case book.IsOverdue(today):
+			counts["overdue"]++
+		case book.IsInCirculation():
+			counts["borrowed"]++
+		}
+	}
+
+	for state, count := range counts {
+		metrics.Books.WithLabelValues(state).Set(float64(count))
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+	"github.com/slack-go/slack"
+)
+
+// SlackNotifier sends direct messages to Slack users by their user name
+type SlackNotifier struct {
+	client  *slack.Client
+	locales *SlackLocaleResolver
+
+	mutex   sync.Mutex
+	userIds map[string]string
+}
+
+func NewSlackNotifier(locales *SlackLocaleResolver, config utils.Config) *SlackNotifier {
+	return &SlackNotifier{
+		client:  slack.New(config.SlackToken),
+		locales: locales,
+		userIds: make(map[string]string),
+	}
+}
+
+// Notify sends the message for the key to the user, in the user's locale
+func (n *SlackNotifier) Notify(ctx context.Context, user string, key string, args ...interface{}) error {
+	userId, err := n.lookupUserId(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	message := i18n.T(n.locales.Locale(ctx, userId, user), key, args...)
+
+	_, _, err = n.client.PostMessageContext(ctx, userId, slack.MsgOptionText(message, false))
+	return err
+}
+
+// lookupUserId resolves a user name to a Slack user ID, refreshing the cached user list on a miss
+func (n *SlackNotifier) lookupUserId(ctx context.Context, user string) (string, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if userId, ok := n.userIds[user]; ok {
+		return userId, nil
+	}
+
+	users, err := n.client.GetUsersContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for _, u := range users {
+		n.userIds[u.Name] = u.ID
+	}
+
+	userId, ok := n.userIds[user]
+	if !ok {
+		return "", fmt.Errorf("slack user %s not found", user)
+	}
+
+	return userId, nil
+}
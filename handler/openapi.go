@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/harrydrippin/go-spreadsheet-library/logging"
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+	views "github.com/harrydrippin/go-spreadsheet-library/view"
+	"github.com/labstack/echo/v4"
+)
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// OpenAPIHandler serves the OpenAPI document of the API and validates API requests against it.
+// Responses are validated too when enabled, which is meant for tests and development.
+type OpenAPIHandler struct {
+	document          *openapi3.T
+	validateResponses bool
+
+	// router is not safe for concurrent use, as it updates the route it found
+	mutex  sync.Mutex
+	router routers.Router
+}
+
+func NewOpenAPIHandler(config utils.Config) *OpenAPIHandler {
+	// 검증 오류 메시지에 스키마 전체가 들어가지 않도록 함
+	openapi3.SchemaErrorDetailsDisabled = true
+
+	document, err := openapi3.NewLoader().LoadFromData(openAPISpec)
+	if err != nil {
+		panic(err)
+	}
+
+	if err = document.Validate(context.Background()); err != nil {
+		panic(err)
+	}
+
+	router, err := gorillamux.NewRouter(document)
+	if err != nil {
+		panic(err)
+	}
+
+	return &OpenAPIHandler{
+		document:          document,
+		validateResponses: config.ValidateAPIResponses,
+		router:            router,
+	}
+}
+
+func (h *OpenAPIHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/openapi.json", h.Document)
+	e.GET("/api/docs", h.Docs)
+	e.Use(h.Validate)
+}
+
+func (h *OpenAPIHandler) Document(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.document)
+}
+
+func (h *OpenAPIHandler) Docs(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return views.RenderAPIDocsPage(c.Response(), views.APIDocsPage{SpecURL: "/api/openapi.json"})
+}
+
+// Validate is a middleware that rejects API requests not matching the OpenAPI document.
+// Routes outside /api or not in the document are left as they are.
+func (h *OpenAPIHandler) Validate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		request := c.Request()
+		if !strings.HasPrefix(request.URL.Path, "/api/") {
+			return next(c)
+		}
+
+		route, pathParams, err := h.findRoute(request)
+		if err != nil {
+			return next(c)
+		}
+
+		// 기존 API 사용자는 Content-Type 없이 JSON을 보내기도 하므로 JSON으로 간주함
+		if route.Operation.RequestBody != nil && request.Header.Get(echo.HeaderContentType) == "" {
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+		}
+		if err := openapi3filter.ValidateRequest(request.Context(), input); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		if !h.validateResponses {
+			return next(c)
+		}
+
+		return h.validateResponse(c, next, input)
+	}
+}
+
+func (h *OpenAPIHandler) findRoute(request *http.Request) (*routers.Route, map[string]string, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	route, pathParams, err := h.router.FindRoute(request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	copied := *route
+	return &copied, pathParams, nil
+}
+
+// validateResponse buffers the response of the handler and replaces it with an error if it does not match the document
+func (h *OpenAPIHandler) validateResponse(c echo.Context, next echo.HandlerFunc, input *openapi3filter.RequestValidationInput) error {
+	response := c.Response()
+	writer := response.Writer
+	recorder := &responseRecorder{ResponseWriter: writer, status: http.StatusOK}
+	response.Writer = recorder
+
+	// 오류 응답도 검증할 수 있도록 여기서 오류 응답을 작성함
+	if err := next(c); err != nil {
+		c.Error(err)
+	}
+	response.Writer = writer
+
+	responseInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 recorder.status,
+		Header:                 writer.Header(),
+		Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+	}
+	err := openapi3filter.ValidateResponse(c.Request().Context(), responseInput.SetBodyBytes(recorder.body.Bytes()))
+	if err != nil {
+		logging.FromContext(c.Request().Context()).WithError(err).WithField("route", c.Path()).Error("Response does not match the OpenAPI document")
+
+		writer.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		writer.WriteHeader(http.StatusInternalServerError)
+		return json.NewEncoder(writer).Encode(ErrorResponse{Code: "invalid_response", Message: err.Error()})
+	}
+
+	writer.WriteHeader(recorder.status)
+	_, err = writer.Write(recorder.body.Bytes())
+	return err
+}
+
+// responseRecorder holds a response back until it is validated
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+	"github.com/labstack/echo/v4"
+)
+
+const testToken = "test-token"
+
+type fakeAuthService struct{}
+
+func (fakeAuthService) IssueToken(user string) (string, time.Time, error) {
+	return testToken, time.Now().Add(time.Hour), nil
+}
+
+func (fakeAuthService) Authenticate(token string) (models.Identity, error) {
+	if token != testToken {
+		return models.Identity{}, i18n.Wrap(models.ErrUnauthenticated, "error.invalid_token")
+	}
+
+	return models.Identity{User: "U1", Role: models.RoleMember, Scopes: []string{models.ScopeRead, models.ScopeWrite}}, nil
+}
+
+// fakeLibraryService serves a fixed set of books. Methods the tests don't call panic through the nil interface.
+type fakeLibraryService struct {
+	services.LibraryUsecase
+	books []models.Book
+	err   error
+}
+
+func (s *fakeLibraryService) Search(ctx context.Context, title string) ([]models.Book, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	books := []models.Book{}
+	for _, book := range s.books {
+		if strings.Contains(book.Title, title) {
+			books = append(books, book)
+		}
+	}
+
+	return books, nil
+}
+
+func (s *fakeLibraryService) SearchById(ctx context.Context, id int) (models.Book, error) {
+	for _, book := range s.books {
+		if book.ID == id {
+			return book, nil
+		}
+	}
+
+	return models.Book{}, i18n.Wrap(models.ErrNotFound, "error.book_not_found", id)
+}
+
+func (s *fakeLibraryService) Borrow(ctx context.Context, book models.Book, borrower string) (models.Book, error) {
+	if book.Status != models.StatusInOffice {
+		return models.Book{}, i18n.Wrap(models.ErrAlreadyBorrowed, "error.already_borrowed")
+	}
+
+	book.Status = models.StatusBorrowed
+	book.Borrower = borrower
+	book.DueDate = "2021-09-30"
+	return book, nil
+}
+
+// newTestServer wires the API routes as main does, with the responses validated against the OpenAPI document
+func newTestServer(library services.LibraryUsecase) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = HandleError
+
+	NewAuthHandler(fakeAuthService{}).RegisterRoutes(e)
+	NewOpenAPIHandler(utils.Config{ValidateAPIResponses: true}).RegisterRoutes(e)
+	NewRESTfulHandler(library).RegisterRoutes(e)
+	NewRESTfulV2Handler(library).RegisterRoutes(e)
+
+	return e
+}
+
+func TestResponsesMatchOpenAPIDocument(t *testing.T) {
+	books := []models.Book{
+		models.NewBook(1, "Go 언어", "Alan", "Insight", "A-1", models.StatusInOffice, "", ""),
+		models.NewBook(2, "Go 인 액션", "Brian", "Insight", "A-2", models.StatusBorrowed, "U2", "2021-09-01"),
+	}
+
+	tests := []struct {
+		name    string
+		library *fakeLibraryService
+		method  string
+		path    string
+		body    string
+		status  int
+		code    string
+	}{
+		{name: "me", method: http.MethodGet, path: "/api/me", status: http.StatusOK},
+		{name: "search", method: http.MethodGet, path: "/api/search?title=Go", status: http.StatusOK},
+		{name: "get book", method: http.MethodGet, path: "/api/v2/books/1", status: http.StatusOK},
+		{name: "borrow", method: http.MethodPost, path: "/api/v2/books/1/borrow", status: http.StatusOK},
+		{name: "borrow by title", method: http.MethodPost, path: "/api/borrow", body: `{"title": "Go 언어"}`, status: http.StatusOK},
+		{name: "book not found", method: http.MethodGet, path: "/api/v2/books/9", status: http.StatusNotFound, code: "not_found"},
+		{name: "already borrowed", method: http.MethodPost, path: "/api/v2/books/2/borrow", status: http.StatusConflict, code: "already_borrowed"},
+		{name: "borrow for others", method: http.MethodPost, path: "/api/v2/books/1/borrow", body: `{"borrower": "U2"}`, status: http.StatusForbidden, code: "forbidden"},
+		{name: "title not found", method: http.MethodPost, path: "/api/borrow", body: `{"title": "Rust"}`, status: http.StatusNotFound, code: "not_found"},
+		{name: "invalid book ID", method: http.MethodGet, path: "/api/v2/books/abc", status: http.StatusBadRequest, code: "bad_request"},
+		{
+			name:    "backend unavailable",
+			library: &fakeLibraryService{err: models.ErrUnavailable},
+			method:  http.MethodGet,
+			path:    "/api/search?title=Go",
+			status:  http.StatusServiceUnavailable,
+			code:    "backend_unavailable",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			library := test.library
+			if library == nil {
+				library = &fakeLibraryService{books: books}
+			}
+
+			request := httptest.NewRequest(test.method, test.path, strings.NewReader(test.body))
+			request.Header.Set(echo.HeaderAuthorization, "Bearer "+testToken)
+			if test.body != "" {
+				request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			}
+			recorder := httptest.NewRecorder()
+			newTestServer(library).ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Fatalf("expected status %d, got %d: %s", test.status, recorder.Code, recorder.Body.String())
+			}
+
+			if test.code == "" {
+				return
+			}
+
+			response := ErrorResponse{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("unable to decode the error response: %v", err)
+			}
+			if response.Code != test.code {
+				t.Errorf("expected code %q, got %q: %s", test.code, response.Code, response.Message)
+			}
+		})
+	}
+}
+
+func TestResponseNotMatchingOpenAPIDocumentIsRejected(t *testing.T) {
+	library := &fakeLibraryService{books: []models.Book{models.NewBook(1, "Go", "", "", "", "대여 중", "", "")}}
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v2/books/1", nil)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+testToken)
+	recorder := httptest.NewRecorder()
+	newTestServer(library).ServeHTTP(recorder, request)
+
+	response := ErrorResponse{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("unable to decode the error response: %v", err)
+	}
+	if recorder.Code != http.StatusInternalServerError || response.Code != "invalid_response" {
+		t.Errorf("expected the invalid status to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
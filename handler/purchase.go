@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	"github.com/labstack/echo/v4"
+)
+
+type PurchaseRequestHandler struct {
+	service services.PurchaseRequestUsecase
+}
+
+func NewPurchaseRequestHandler(service services.PurchaseRequestUsecase) *PurchaseRequestHandler {
+	return &PurchaseRequestHandler{service: service}
+}
+
+func (h *PurchaseRequestHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/requests", h.List)
+	e.POST("/api/requests", h.Submit)
+	e.POST("/api/requests/:id/vote", h.Vote)
+	e.POST("/api/requests/:id/approve", h.Approve, requireScope(models.ScopeAdmin))
+	e.POST("/api/requests/:id/reject", h.Reject, requireScope(models.ScopeAdmin))
+	e.POST("/api/requests/:id/purchase", h.MarkPurchased, requireScope(models.ScopeAdmin))
+}
+
+func (h *PurchaseRequestHandler) List(c echo.Context) error {
+	requests, err := h.service.List(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, requests)
+}
+
+func (h *PurchaseRequestHandler) Submit(c echo.Context) error {
+	params := make(map[string]string)
+	err := json.NewDecoder(c.Request().Body).Decode(&params)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	requester, err := actingUser(c, params["requester"])
+	if err != nil {
+		return err
+	}
+
+	request, err := h.service.Submit(c.Request().Context(), params["title"], params["author"], requester)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, request)
+}
+
+func (h *PurchaseRequestHandler) Vote(c echo.Context) error {
+	return h.handleAction(c, func(id int, params map[string]string) (models.PurchaseRequest, error) {
+		voter, err := actingUser(c, params["voter"])
+		if err != nil {
+			return models.PurchaseRequest{}, err
+		}
+
+		return h.service.Vote(c.Request().Context(), id, voter)
+	})
+}
+
+func (h *PurchaseRequestHandler) Approve(c echo.Context) error {
+	return h.handleAction(c, func(id int, params map[string]string) (models.PurchaseRequest, error) {
+		return h.service.Approve(c.Request().Context(), id, identityOf(c).User)
+	})
+}
+
+func (h *PurchaseRequestHandler) Reject(c echo.Context) error {
+	return h.handleAction(c, func(id int, params map[string]string) (models.PurchaseRequest, error) {
+		return h.service.Reject(c.Request().Context(), id, identityOf(c).User, params["reason"])
+	})
+}
+
+func (h *PurchaseRequestHandler) MarkPurchased(c echo.Context) error {
+	return h.handleAction(c, func(id int, params map[string]string) (models.PurchaseRequest, error) {
+		return h.service.MarkPurchased(c.Request().Context(), id, identityOf(c).User)
+	})
+}
+
+// handleAction parses the request ID and JSON body, then applies the action to the purchase request
+func (h *PurchaseRequestHandler) handleAction(c echo.Context, action func(int, map[string]string) (models.PurchaseRequest, error)) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request id")
+	}
+
+	// 승인처럼 본문이 필요 없는 처리는 본문 없이 호출할 수 있음
+	params := make(map[string]string)
+	err = json.NewDecoder(c.Request().Body).Decode(&params)
+	if err != nil && err != io.EOF {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	request, err := action(id, params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
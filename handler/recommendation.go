@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	"github.com/labstack/echo/v4"
+)
+
+type RecommendationHandler struct {
+	service services.RecommendationUsecase
+}
+
+func NewRecommendationHandler(service services.RecommendationUsecase) *RecommendationHandler {
+	return &RecommendationHandler{service: service}
+}
+
+func (h *RecommendationHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/recommendations", h.Recommend)
+}
+
+func (h *RecommendationHandler) Recommend(c echo.Context) error {
+	user, err := actingUser(c, c.QueryParam("user"))
+	if err != nil {
+		return err
+	}
+
+	limit := 5
+	if c.QueryParam("limit") != "" {
+		var err error
+		limit, err = strconv.Atoi(c.QueryParam("limit"))
+		if err != nil || limit < 1 || limit > 50 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Limit must be between 1 and 50")
+		}
+	}
+
+	recommendations, err := h.service.Recommend(c.Request().Context(), user, limit)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, recommendations)
+}
@@ -3,8 +3,10 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
 
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
 	services "github.com/harrydrippin/go-spreadsheet-library/service"
 	"github.com/labstack/echo/v4"
 )
@@ -24,6 +26,8 @@ func (h *RESTfulHandler) RegisterRoutes(e *echo.Echo) {
 	e.POST("/api/return", h.Return)
 	e.POST("/api/extend", h.Extend)
 	e.GET("/api/status", h.Status)
+	e.POST("/api/books/:id/status", h.ChangeStatus, requireScope(models.ScopeAdmin))
+	e.GET("/api/lost", h.LostBooks, requireScope(models.ScopeAdmin))
 }
 
 func (h *RESTfulHandler) Healthcheck(c echo.Context) error {
@@ -32,26 +36,36 @@ func (h *RESTfulHandler) Healthcheck(c echo.Context) error {
 
 func (h *RESTfulHandler) Search(c echo.Context) error {
 	title := c.QueryParam("title")
-	books, err := h.service.Search(title)
+	search := h.service.Search
+	if c.QueryParam("all") == "true" {
+		search = h.service.SearchAll
+	}
+
+	books, err := search(c.Request().Context(), title)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	return c.JSON(http.StatusOK, books)
 }
 
 func (h *RESTfulHandler) Borrow(c echo.Context) error {
+	ctx := c.Request().Context()
 	params := make(map[string]string)
 	err := json.NewDecoder(c.Request().Body).Decode(&params)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	title, borrower := params["title"], params["borrower"]
+	title := params["title"]
+	borrower, err := actingUser(c, params["borrower"])
+	if err != nil {
+		return err
+	}
 
-	books, err := h.service.Search(title)
+	books, err := h.service.Search(ctx, title)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	if len(books) == 0 {
@@ -63,26 +77,31 @@ func (h *RESTfulHandler) Borrow(c echo.Context) error {
 	}
 
 	book := books[0]
-	book, err = h.service.Borrow(book, borrower)
+	book, err = h.service.Borrow(ctx, book, borrower)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+		return err
 	}
 
 	return c.JSON(http.StatusOK, book)
 }
 
 func (h *RESTfulHandler) Return(c echo.Context) error {
+	ctx := c.Request().Context()
 	params := make(map[string]string)
 	err := json.NewDecoder(c.Request().Body).Decode(&params)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	title, borrower := params["title"], params["borrower"]
+	title := params["title"]
+	borrower, err := actingUser(c, params["borrower"])
+	if err != nil {
+		return err
+	}
 
-	books, err := h.service.Search(title)
+	books, err := h.service.Search(ctx, title)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	if len(books) == 0 {
@@ -94,29 +113,31 @@ func (h *RESTfulHandler) Return(c echo.Context) error {
 	}
 
 	book := books[0]
-	if book.Borrower != borrower {
-		return echo.NewHTTPError(http.StatusBadRequest, "Book not borrowed by that borrower")
-	}
-
-	book, err = h.service.Return(book, borrower)
+	book, err = h.service.Return(ctx, book, borrower)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+		return err
 	}
 
 	return c.JSON(http.StatusOK, book)
 }
 
 func (h *RESTfulHandler) Extend(c echo.Context) error {
+	ctx := c.Request().Context()
 	params := make(map[string]string)
 	err := json.NewDecoder(c.Request().Body).Decode(&params)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	title, borrower := params["title"], params["borrower"]
-	books, err := h.service.Search(title)
+	title := params["title"]
+	borrower, err := actingUser(c, params["borrower"])
+	if err != nil {
+		return err
+	}
+
+	books, err := h.service.Search(ctx, title)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	if len(books) == 0 {
@@ -128,25 +149,64 @@ func (h *RESTfulHandler) Extend(c echo.Context) error {
 	}
 
 	book := books[0]
-	if book.Borrower != borrower {
-		return echo.NewHTTPError(http.StatusBadRequest, "Book not borrowed by that borrower")
-	}
-
-	book, err = h.service.Extend(book, borrower)
+	book, err = h.service.Extend(ctx, book, borrower)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+		return err
 	}
 
 	return c.JSON(http.StatusOK, book)
 }
 
 func (h *RESTfulHandler) Status(c echo.Context) error {
-	borrower := c.QueryParam("borrower")
+	borrower, err := actingUser(c, c.QueryParam("borrower"))
+	if err != nil {
+		return err
+	}
 
-	books, err := h.service.Status(borrower)
+	books, err := h.service.Status(c.Request().Context(), borrower)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	return c.JSON(http.StatusOK, books)
 }
+
+func (h *RESTfulHandler) ChangeStatus(c echo.Context) error {
+	ctx := c.Request().Context()
+	bookId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid book id")
+	}
+
+	params := make(map[string]string)
+	err = json.NewDecoder(c.Request().Body).Decode(&params)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	book, err := h.service.SearchById(ctx, bookId)
+	if err != nil {
+		return err
+	}
+
+	status, err := models.ParseStatus(params["status"])
+	if err != nil {
+		return err
+	}
+
+	book, err = h.service.ChangeStatus(ctx, book, status, identityOf(c).User)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, book)
+}
+
+func (h *RESTfulHandler) LostBooks(c echo.Context) error {
+	lost, err := h.service.LostBooks(c.Request().Context(), identityOf(c).User)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, lost)
+}
@@ -0,0 +1,306 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	"github.com/labstack/echo/v4"
+)
+
+// RESTfulV2Handler serves the resource-oriented API, where books are addressed by their ID instead of a fuzzy title
+type RESTfulV2Handler struct {
+	service services.LibraryUsecase
+}
+
+func NewRESTfulV2Handler(service services.LibraryUsecase) *RESTfulV2Handler {
+	return &RESTfulV2Handler{service: service}
+}
+
+func (h *RESTfulV2Handler) RegisterRoutes(e *echo.Echo) {
+	v2 := e.Group("/api/v2")
+	v2.GET("/books", h.ListBooks)
+	v2.POST("/books", h.AddBook, requireScope(models.ScopeAdmin))
+	v2.GET("/books/:id", h.GetBook)
+	v2.PATCH("/books/:id", h.EditBook, requireScope(models.ScopeAdmin))
+	v2.DELETE("/books/:id", h.DeleteBook, requireScope(models.ScopeAdmin))
+	v2.POST("/books/:id/borrow", h.Borrow)
+	v2.POST("/books/:id/return", h.Return)
+	v2.POST("/books/:id/extend", h.Extend)
+	v2.POST("/books/:id/force-return", h.ForceReturn, requireScope(models.ScopeAdmin))
+	v2.PUT("/books/:id/due-date", h.ChangeDueDate, requireScope(models.ScopeAdmin))
+	v2.PUT("/books/:id/borrower", h.Reassign, requireScope(models.ScopeAdmin))
+	v2.POST("/books/:id/archive", h.Archive, requireScope(models.ScopeAdmin))
+	v2.GET("/books/:id/audit", h.AuditTrail, requireScope(models.ScopeAdmin))
+	v2.GET("/audit", h.AuditTrail, requireScope(models.ScopeAdmin))
+	v2.GET("/users/:id/loans", h.Loans)
+}
+
+// ListBooks returns the books in circulation, optionally filtered by title and status.
+// Books out of circulation are included with all=true.
+func (h *RESTfulV2Handler) ListBooks(c echo.Context) error {
+	ctx := c.Request().Context()
+	all := c.QueryParam("all") == "true"
+
+	var books []models.Book
+	var err error
+	switch {
+	case c.QueryParam("title") != "" && all:
+		books, err = h.service.SearchAll(ctx, c.QueryParam("title"))
+	case c.QueryParam("title") != "":
+		books, err = h.service.Search(ctx, c.QueryParam("title"))
+	default:
+		books, err = h.service.List(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	var status models.Status
+	if c.QueryParam("status") != "" {
+		status, err = models.ParseStatus(c.QueryParam("status"))
+		if err != nil {
+			return err
+		}
+	}
+
+	result := []models.Book{}
+	for _, book := range books {
+		if !all && !book.IsInCirculation() {
+			continue
+		}
+		if status != "" && book.Status != status {
+			continue
+		}
+		result = append(result, book)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *RESTfulV2Handler) GetBook(c echo.Context) error {
+	book, err := h.findBook(c)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, book)
+}
+
+// AddBook adds the book in the JSON body to the library
+func (h *RESTfulV2Handler) AddBook(c echo.Context) error {
+	details := models.BookDetails{}
+	err := json.NewDecoder(c.Request().Body).Decode(&details)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	book, err := h.service.AddBook(c.Request().Context(), details, identityOf(c).User)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, book)
+}
+
+// EditBook changes the details of the book given in the JSON body, leaving the others as they are
+func (h *RESTfulV2Handler) EditBook(c echo.Context) error {
+	book, err := h.findBook(c)
+	if err != nil {
+		return err
+	}
+
+	details := models.BookDetails{}
+	err = json.NewDecoder(c.Request().Body).Decode(&details)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	book, err = h.service.EditDetails(c.Request().Context(), book, details, identityOf(c).User)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, book)
+}
+
+func (h *RESTfulV2Handler) DeleteBook(c echo.Context) error {
+	book, err := h.findBook(c)
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Delete(c.Request().Context(), book, identityOf(c).User)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *RESTfulV2Handler) Borrow(c echo.Context) error {
+	return h.handleLoanAction(c, h.service.Borrow)
+}
+
+func (h *RESTfulV2Handler) Return(c echo.Context) error {
+	return h.handleLoanAction(c, h.service.Return)
+}
+
+func (h *RESTfulV2Handler) Extend(c echo.Context) error {
+	return h.handleLoanAction(c, h.service.Extend)
+}
+
+// ForceReturn returns the book for whoever borrowed it
+func (h *RESTfulV2Handler) ForceReturn(c echo.Context) error {
+	book, err := h.findBook(c)
+	if err != nil {
+		return err
+	}
+
+	book, err = h.service.ForceReturn(c.Request().Context(), book, identityOf(c).User)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, book)
+}
+
+func (h *RESTfulV2Handler) ChangeDueDate(c echo.Context) error {
+	book, err := h.findBook(c)
+	if err != nil {
+		return err
+	}
+
+	params := make(map[string]string)
+	err = json.NewDecoder(c.Request().Body).Decode(&params)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	book, err = h.service.ChangeDueDate(c.Request().Context(), book, params["due_date"], identityOf(c).User)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, book)
+}
+
+// Reassign hands the borrowed book over to the borrower in the JSON body
+func (h *RESTfulV2Handler) Reassign(c echo.Context) error {
+	book, err := h.findBook(c)
+	if err != nil {
+		return err
+	}
+
+	params := make(map[string]string)
+	err = json.NewDecoder(c.Request().Body).Decode(&params)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	book, err = h.service.Reassign(c.Request().Context(), book, params["borrower"], identityOf(c).User)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, book)
+}
+
+func (h *RESTfulV2Handler) Archive(c echo.Context) error {
+	book, err := h.findBook(c)
+	if err != nil {
+		return err
+	}
+
+	book, err = h.service.Archive(c.Request().Context(), book, identityOf(c).User)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, book)
+}
+
+// AuditTrail returns the changes made by librarians and admins to the book, or to every book without a book ID
+func (h *RESTfulV2Handler) AuditTrail(c echo.Context) error {
+	// 삭제된 책의 기록도 볼 수 있도록 책을 찾지 않고 번호만 확인함
+	bookId := 0
+	if c.Param("id") != "" {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid book id")
+		}
+		bookId = id
+	}
+
+	entries, err := h.service.AuditTrail(c.Request().Context(), bookId, identityOf(c).User)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, entries)
+}
+
+// Loans returns the loan history of the user, or only the books not returned yet with open=true
+func (h *RESTfulV2Handler) Loans(c echo.Context) error {
+	user, err := actingUser(c, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	loans, err := h.service.Loans(c.Request().Context(), user)
+	if err != nil {
+		return err
+	}
+
+	if c.QueryParam("open") == "true" {
+		open := []models.Loan{}
+		for _, loan := range loans {
+			if loan.IsOpen() {
+				open = append(open, loan)
+			}
+		}
+		loans = open
+	}
+
+	return c.JSON(http.StatusOK, loans)
+}
+
+func (h *RESTfulV2Handler) findBook(c echo.Context) (models.Book, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return models.Book{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid book id")
+	}
+
+	return h.service.SearchById(c.Request().Context(), id)
+}
+
+// handleLoanAction finds the book by its ID and applies the action for the caller, or for the borrower in the JSON body if an admin names one
+func (h *RESTfulV2Handler) handleLoanAction(c echo.Context, action func(context.Context, models.Book, string) (models.Book, error)) error {
+	book, err := h.findBook(c)
+	if err != nil {
+		return err
+	}
+
+	// 본문 없이 호출하면 호출한 사용자로 처리함
+	params := make(map[string]string)
+	err = json.NewDecoder(c.Request().Body).Decode(&params)
+	if err != nil && err != io.EOF {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	borrower, err := actingUser(c, params["borrower"])
+	if err != nil {
+		return err
+	}
+
+	book, err = action(c.Request().Context(), book, borrower)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, book)
+}
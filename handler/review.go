@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	"github.com/labstack/echo/v4"
+)
+
+type ReviewHandler struct {
+	service services.ReviewUsecase
+}
+
+func NewReviewHandler(service services.ReviewUsecase) *ReviewHandler {
+	return &ReviewHandler{service: service}
+}
+
+func (h *ReviewHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/books/:id/reviews", h.Reviews)
+	e.POST("/api/books/:id/reviews", h.Rate)
+}
+
+func (h *ReviewHandler) Reviews(c echo.Context) error {
+	ctx := c.Request().Context()
+	bookId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid book id")
+	}
+
+	summary, err := h.service.Summary(ctx, bookId)
+	if err != nil {
+		return err
+	}
+
+	reviews, err := h.service.Reviews(ctx, bookId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"summary": summary, "reviews": reviews})
+}
+
+func (h *ReviewHandler) Rate(c echo.Context) error {
+	bookId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid book id")
+	}
+
+	params := struct {
+		Reviewer string `json:"reviewer"`
+		Rating   int    `json:"rating"`
+		Comment  string `json:"comment"`
+	}{}
+	err = json.NewDecoder(c.Request().Body).Decode(&params)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	reviewer, err := actingUser(c, params.Reviewer)
+	if err != nil {
+		return err
+	}
+
+	review, err := h.service.Rate(c.Request().Context(), bookId, reviewer, params.Rating, params.Comment)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, review)
+}
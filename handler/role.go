@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"sync"
+	"time"
+
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+	"github.com/slack-go/slack"
+)
+
+// slackGroupTTL is how long the members of the Slack user groups are cached
+const slackGroupTTL = 10 * time.Minute
+
+// SlackRoleResolver gives roles to the members of Slack user groups, on top of the roles in the configuration.
+// A user gets the most privileged of the roles.
+type SlackRoleResolver struct {
+	client     *slack.Client
+	configured services.RoleResolver
+	groups     map[models.Role]string
+
+	mutex     sync.Mutex
+	members   map[string]models.Role
+	fetchedAt time.Time
+}
+
+func NewSlackRoleResolver(configured services.RoleResolver, config utils.Config) *SlackRoleResolver {
+	groups := make(map[models.Role]string)
+	if config.SlackLibrarianGroup != "" {
+		groups[models.RoleLibrarian] = config.SlackLibrarianGroup
+	}
+	if config.SlackAdminGroup != "" {
+		groups[models.RoleAdmin] = config.SlackAdminGroup
+	}
+
+	return &SlackRoleResolver{
+		client:     slack.New(config.SlackToken),
+		configured: configured,
+		groups:     groups,
+	}
+}
+
+// Role returns the role of the Slack user with the given name
+func (r *SlackRoleResolver) Role(user string) models.Role {
+	role := r.configured.Role(user)
+	if len(r.groups) == 0 {
+		return role
+	}
+
+	r.mutex.Lock()
+	expired := time.Since(r.fetchedAt) > slackGroupTTL
+	if expired {
+		// 조회하는 동안 다른 요청은 이전 목록을 쓰도록 조회 시각을 먼저 갱신함
+		r.fetchedAt = time.Now()
+	}
+	r.mutex.Unlock()
+
+	if expired {
+		// 느린 Slack 호출이 다른 권한 확인을 막지 않도록 잠금을 풀고 조회함.
+		// 조회에 실패하면 이전 목록을 그대로 쓰고 다음 주기에 다시 조회함
+		if members, err := r.fetchMembers(); err == nil {
+			r.mutex.Lock()
+			r.members = members
+			r.mutex.Unlock()
+		}
+	}
+
+	r.mutex.Lock()
+	groupRole, ok := r.members[user]
+	r.mutex.Unlock()
+	if ok && groupRole.Includes(role) {
+		return groupRole
+	}
+
+	return role
+}
+
+// fetchMembers returns the role of each member of the user groups by their user name
+func (r *SlackRoleResolver) fetchMembers() (map[string]models.Role, error) {
+	members := make(map[string]models.Role)
+	for _, role := range models.Roles {
+		group, ok := r.groups[role]
+		if !ok {
+			continue
+		}
+
+		userIds, err := r.client.GetUserGroupMembers(group)
+		if err != nil {
+			return nil, err
+		}
+		if len(userIds) == 0 {
+			continue
+		}
+
+		// 명령어와 API는 사용자 이름으로 사용자를 구분하므로 ID를 이름으로 바꿈
+		users, err := r.client.GetUsersInfo(userIds...)
+		if err != nil {
+			return nil, err
+		}
+
+		// 권한이 낮은 역할부터 처리하므로 여러 그룹에 속한 사용자는 가장 높은 역할을 받음
+		for _, user := range *users {
+			members[user.Name] = role
+		}
+	}
+
+	return members, nil
+}
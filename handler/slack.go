@@ -1,30 +1,103 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	"github.com/harrydrippin/go-spreadsheet-library/logging"
+	"github.com/harrydrippin/go-spreadsheet-library/metrics"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
 	services "github.com/harrydrippin/go-spreadsheet-library/service"
 	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
 	views "github.com/harrydrippin/go-spreadsheet-library/view"
 	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
 	"github.com/slack-go/slack"
 )
 
 type SlackHandler struct {
 	SigningSecret string
 
-	client  *slack.Client
-	userId  string
-	service services.LibraryUsecase
+	client           *slack.Client
+	userId           string
+	service          services.LibraryUsecase
+	purchaseService  services.PurchaseRequestUsecase
+	reviewService    services.ReviewUsecase
+	recommender      services.RecommendationUsecase
+	stocktakeService services.StocktakeUsecase
+	preferences      services.PreferenceUsecase
+	authService      services.AuthUsecase
+	roles            services.RoleResolver
+	locales          *SlackLocaleResolver
 }
 
-func NewSlackHandler(service services.LibraryUsecase, config utils.Config) *SlackHandler {
+// commandAliases maps English subcommands to their Korean counterparts
+var commandAliases = map[string]string{
+	"search":       "검색",
+	"status":       "현황",
+	"recommend":    "추천",
+	"request":      "신청",
+	"requests":     "신청목록",
+	"approve":      "신청승인",
+	"reject":       "신청반려",
+	"purchased":    "구매완료",
+	"set-status":   "상태변경",
+	"lost":         "분실현황",
+	"stocktake":    "재고조사",
+	"seen":         "확인",
+	"language":     "언어",
+	"token":        "토큰",
+	"force-return": "강제반납",
+	"due-date":     "기한변경",
+	"edit":         "정보수정",
+	"delete":       "삭제",
+	"role":         "권한",
+}
+
+// slackActions are the action and callback IDs counted in the metrics, which come from the messages and modals of the bot
+var slackActions = map[string]bool{
+	utils.BorrowThisBook:  true,
+	utils.ReturnThisBook:  true,
+	utils.ExtendThisBook:  true,
+	utils.RequestThisBook: true,
+	utils.VoteThisRequest: true,
+	utils.RateThisBook:    true,
+	utils.RateBookModal:   true,
+}
+
+// bookFields maps the names of the book details a librarian can edit to their English names
+var bookFields = map[string]string{
+	"제목":        "title",
+	"저자":        "author",
+	"출판사":       "publisher",
+	"위치":        "position",
+	"title":     "title",
+	"author":    "author",
+	"publisher": "publisher",
+	"position":  "position",
+}
+
+// stocktakeAliases maps English stocktake actions to their Korean counterparts
+var stocktakeAliases = map[string]string{
+	"start":  "시작",
+	"report": "현황",
+	"close":  "종료",
+}
+
+// requestActionUsages maps purchase request actions to the usage message of each
+var requestActionUsages = map[string]string{
+	"신청승인": "usage.approve",
+	"신청반려": "usage.reject",
+	"구매완료": "usage.purchased",
+}
+
+func NewSlackHandler(service services.LibraryUsecase, purchaseService services.PurchaseRequestUsecase, reviewService services.ReviewUsecase, recommender services.RecommendationUsecase, stocktakeService services.StocktakeUsecase, preferences services.PreferenceUsecase, authService services.AuthUsecase, roles services.RoleResolver, locales *SlackLocaleResolver, config utils.Config) *SlackHandler {
 	client := slack.New(config.SlackToken)
 	bot, err := client.AuthTest()
 	if err != nil {
@@ -33,10 +106,18 @@ func NewSlackHandler(service services.LibraryUsecase, config utils.Config) *Slac
 	userId := bot.UserID
 
 	return &SlackHandler{
-		SigningSecret: config.SlackSigningSecret,
-		client:        client,
-		userId:        userId,
-		service:       service,
+		SigningSecret:    config.SlackSigningSecret,
+		client:           client,
+		userId:           userId,
+		service:          service,
+		purchaseService:  purchaseService,
+		reviewService:    reviewService,
+		recommender:      recommender,
+		stocktakeService: stocktakeService,
+		preferences:      preferences,
+		authService:      authService,
+		roles:            roles,
+		locales:          locales,
 	}
 }
 
@@ -46,11 +127,13 @@ func (h *SlackHandler) RegisterRoutes(e *echo.Echo) {
 }
 
 func (h *SlackHandler) HandleCommands(c echo.Context) error {
+	ctx := c.Request().Context()
 	header := c.Request().Header
 
 	verifier, err := slack.NewSecretsVerifier(header, h.SigningSecret)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		// 서명 헤더가 없거나 오래된 요청
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
 	}
 
 	c.Request().Body = ioutil.NopCloser(io.TeeReader(c.Request().Body, &verifier))
@@ -60,132 +143,583 @@ func (h *SlackHandler) HandleCommands(c echo.Context) error {
 	}
 
 	if err = verifier.Ensure(); err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		return invalidSignature(ctx, err)
 	}
 
-	if slackCommand.Command != "/도서관" {
+	if slackCommand.Command != "/도서관" && slackCommand.Command != "/library" {
 		return echo.NewHTTPError(http.StatusBadRequest, "Command not supported")
 	}
 
 	userName := slackCommand.UserName
+	locale := h.locales.Locale(ctx, slackCommand.UserID, userName)
 	command := strings.Split(slackCommand.Text, " ")
+	if alias, ok := commandAliases[command[0]]; ok {
+		command[0] = alias
+	}
+	metrics.SlackCommands.WithLabelValues(commandMetric(command[0])).Inc()
+	if command[0] == "재고조사" && len(command) > 1 {
+		if alias, ok := stocktakeAliases[command[1]]; ok {
+			command[1] = alias
+		}
+	}
 	switch command[0] {
 	case "검색":
 		if len(command) <= 1 {
-			return c.String(http.StatusOK, "명령이 잘못되었어요.\n사용 방법: /도서관 검색 `<검색어>`")
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.search")))
 		}
 
 		query := strings.Join(command[1:], " ")
-		books, err := h.service.Search(query)
+		books, err := h.service.Search(ctx, query)
+		if err != nil {
+			return commandFailed(c, locale, err)
+		}
+
+		bookIds := make([]int, 0, len(books))
+		for _, book := range books {
+			bookIds = append(bookIds, book.ID)
+		}
+
+		// 평점을 불러오지 못하더라도 검색 결과는 보여줌
+		summaries, err := h.reviewService.Summaries(ctx, bookIds)
 		if err != nil {
-			return c.String(http.StatusOK, "서버 오류가 발생했어요. :( 나중에 다시 시도하세요.")
+			logging.FromContext(ctx).WithError(err).Warn("Unable to load the ratings of the search result")
+			summaries = map[int]models.ReviewSummary{}
 		}
 
-		msg := views.RenderSearchResult(query, books)
+		msg := views.RenderSearchResult(query, books, summaries, locale)
 		b, err := json.MarshalIndent(msg, "", "    ")
 		if err != nil {
-			return c.String(http.StatusOK, "서버 오류가 발생했어요. :( 나중에 다시 시도하세요.")
+			return commandFailed(c, locale, err)
 		}
 
 		return c.JSONBlob(http.StatusOK, b)
 
 	case "현황":
 		if len(command) != 1 {
-			return c.String(http.StatusOK, "명령이 잘못되었어요.\n사용 방법: /도서관 현황")
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.status")))
 		}
 
-		books, err := h.service.Status(userName)
+		books, err := h.service.Status(ctx, userName)
 		if err != nil {
-			return c.String(http.StatusOK, "서버 오류가 발생했어요. :( 나중에 다시 시도하세요.")
+			return commandFailed(c, locale, err)
 		}
 
-		msg := views.RenderStatusResult(books, userName)
+		msg := views.RenderStatusResult(books, userName, locale)
 		b, err := json.MarshalIndent(msg, "", "    ")
 		if err != nil {
-			return c.String(http.StatusOK, "서버 오류가 발생했어요. :( 나중에 다시 시도하세요.")
+			return commandFailed(c, locale, err)
 		}
 
 		return c.JSONBlob(http.StatusOK, b)
+
+	case "추천":
+		if len(command) != 1 {
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.recommend")))
+		}
+
+		recommendations, err := h.recommender.Recommend(ctx, userName, 5)
+		if err != nil {
+			return commandFailed(c, locale, err)
+		}
+
+		return renderSlackMessage(c, views.RenderRecommendationResult(recommendations, userName, locale), locale)
+
+	case "신청":
+		if len(command) <= 1 {
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.request")))
+		}
+
+		title, author := strings.Join(command[1:], " "), ""
+		if parts := strings.SplitN(title, "/", 2); len(parts) == 2 {
+			title, author = parts[0], parts[1]
+		}
+
+		request, err := h.purchaseService.Submit(ctx, title, author, userName)
+		if err != nil {
+			return c.String(http.StatusOK, i18n.Message(locale, err))
+		}
+
+		return renderSlackMessage(c, views.RenderPurchaseRequestResult(request, locale), locale)
+
+	case "신청목록":
+		if len(command) != 1 {
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.requests")))
+		}
+
+		requests, err := h.purchaseService.List(ctx)
+		if err != nil {
+			return commandFailed(c, locale, err)
+		}
+
+		return renderSlackMessage(c, views.RenderPurchaseRequestList(requests, userName, locale), locale)
+
+	case "신청승인", "신청반려", "구매완료":
+		if len(command) <= 1 {
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, requestActionUsages[command[0]])))
+		}
+
+		requestId, err := strconv.Atoi(command[1])
+		if err != nil {
+			return c.String(http.StatusOK, i18n.T(locale, "error.request_id_number"))
+		}
+
+		var request models.PurchaseRequest
+		switch command[0] {
+		case "신청승인":
+			request, err = h.purchaseService.Approve(ctx, requestId, userName)
+		case "신청반려":
+			request, err = h.purchaseService.Reject(ctx, requestId, userName, strings.Join(command[2:], " "))
+		case "구매완료":
+			request, err = h.purchaseService.MarkPurchased(ctx, requestId, userName)
+		}
+		if err != nil {
+			return c.String(http.StatusOK, i18n.Message(locale, err))
+		}
+
+		return c.String(http.StatusOK, i18n.T(locale, "request.status_changed", request.ID, request.Title, request.Status))
+
+	case "상태변경":
+		if len(command) <= 2 {
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.set_status")))
+		}
+
+		bookId, err := strconv.Atoi(command[1])
+		if err != nil {
+			return c.String(http.StatusOK, i18n.T(locale, "error.book_id_number"))
+		}
+
+		book, err := h.service.SearchById(ctx, bookId)
+		if err != nil {
+			return c.String(http.StatusOK, i18n.T(locale, "error.book_not_found", bookId))
+		}
+
+		status, err := models.ParseStatus(strings.Join(command[2:], " "))
+		if err != nil {
+			return c.String(http.StatusOK, i18n.T(locale, "error.unknown_status"))
+		}
+
+		book, err = h.service.ChangeStatus(ctx, book, status, userName)
+		if err != nil {
+			return c.String(http.StatusOK, i18n.Message(locale, err))
+		}
+
+		return c.String(http.StatusOK, i18n.T(locale, "book.status_changed", book.ID, book.Title, book.Status))
+
+	case "강제반납":
+		if len(command) != 2 {
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.force_return")))
+		}
+
+		book, msg := h.commandBook(ctx, command[1], locale)
+		if msg != "" {
+			return c.String(http.StatusOK, msg)
+		}
+
+		borrower := book.Borrower
+		book, err := h.service.ForceReturn(ctx, book, userName)
+		if err != nil {
+			return c.String(http.StatusOK, i18n.Message(locale, err))
+		}
+
+		return c.String(http.StatusOK, i18n.T(locale, "book.force_returned", book.ID, book.Title, borrower))
+
+	case "기한변경":
+		if len(command) != 3 {
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.due_date")))
+		}
+
+		book, msg := h.commandBook(ctx, command[1], locale)
+		if msg != "" {
+			return c.String(http.StatusOK, msg)
+		}
+
+		book, err := h.service.ChangeDueDate(ctx, book, command[2], userName)
+		if err != nil {
+			return c.String(http.StatusOK, i18n.Message(locale, err))
+		}
+
+		return c.String(http.StatusOK, i18n.T(locale, "book.due_date_changed", book.ID, book.Title, book.DueDate))
+
+	case "정보수정":
+		if len(command) <= 3 {
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.edit")))
+		}
+
+		book, msg := h.commandBook(ctx, command[1], locale)
+		if msg != "" {
+			return c.String(http.StatusOK, msg)
+		}
+
+		value := strings.Join(command[3:], " ")
+		details := models.BookDetails{}
+		switch bookFields[strings.ToLower(command[2])] {
+		case "title":
+			details.Title = value
+		case "author":
+			details.Author = value
+		case "publisher":
+			details.Publisher = value
+		case "position":
+			details.Position = value
+		default:
+			return c.String(http.StatusOK, i18n.T(locale, "error.unknown_field"))
+		}
+
+		book, err := h.service.EditDetails(ctx, book, details, userName)
+		if err != nil {
+			return c.String(http.StatusOK, i18n.Message(locale, err))
+		}
+
+		return c.String(http.StatusOK, i18n.T(locale, "book.edited", book.ID, book.Title, book.Author, book.Publisher, book.Position))
+
+	case "삭제":
+		if len(command) != 2 {
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.delete")))
+		}
+
+		book, msg := h.commandBook(ctx, command[1], locale)
+		if msg != "" {
+			return c.String(http.StatusOK, msg)
+		}
+
+		if err := h.service.Delete(ctx, book, userName); err != nil {
+			return c.String(http.StatusOK, i18n.Message(locale, err))
+		}
+
+		return c.String(http.StatusOK, i18n.T(locale, "book.deleted", book.ID, book.Title))
+
+	case "권한":
+		if len(command) != 1 {
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.role")))
+		}
+
+		return c.String(http.StatusOK, i18n.T(locale, "role.current", userName, h.roles.Role(userName)))
+
+	case "분실현황":
+		if len(command) != 1 {
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.lost")))
+		}
+
+		lost, err := h.service.LostBooks(ctx, userName)
+		if err != nil {
+			return commandFailed(c, locale, err)
+		}
+
+		return renderSlackMessage(c, views.RenderLostBooks(lost, locale), locale)
+
+	case "재고조사":
+		if len(command) != 2 {
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.stocktake")))
+		}
+
+		switch command[1] {
+		case "시작":
+			session, err := h.stocktakeService.Open(ctx, userName)
+			if err != nil {
+				return c.String(http.StatusOK, i18n.Message(locale, err))
+			}
+
+			return c.String(http.StatusOK, i18n.T(locale, "stocktake.opened", session.ID))
+		case "현황", "종료":
+			var report models.StocktakeReport
+			if command[1] == "현황" {
+				report, err = h.stocktakeService.Report(ctx)
+			} else {
+				report, err = h.stocktakeService.Close(ctx, userName)
+			}
+			if err != nil {
+				return c.String(http.StatusOK, i18n.Message(locale, err))
+			}
+
+			return renderSlackMessage(c, views.RenderStocktakeReport(report, locale), locale)
+		default:
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.stocktake")))
+		}
+
+	case "확인":
+		if len(command) <= 1 {
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.seen")))
+		}
+
+		bookId, err := strconv.Atoi(command[1])
+		if err != nil {
+			return c.String(http.StatusOK, i18n.T(locale, "error.book_id_number"))
+		}
+
+		book, err := h.stocktakeService.MarkSeen(ctx, bookId, strings.Join(command[2:], " "), userName)
+		if err != nil {
+			return c.String(http.StatusOK, i18n.Message(locale, err))
+		}
+
+		return c.String(http.StatusOK, i18n.T(locale, "stocktake.seen", book.ID, book.Title))
+
+	case "언어":
+		if len(command) != 2 {
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.language")))
+		}
+
+		changed, err := h.preferences.SetLocale(ctx, userName, command[1])
+		if err != nil {
+			return c.String(http.StatusOK, i18n.Message(locale, err))
+		}
+
+		return c.String(http.StatusOK, i18n.T(changed, "language.changed"))
+
+	case "토큰":
+		if len(command) != 1 {
+			return c.String(http.StatusOK, i18n.T(locale, "error.usage", i18n.T(locale, "usage.token")))
+		}
+
+		// 명령어의 응답은 요청한 사용자에게만 보이므로 토큰을 그대로 전달함
+		token, expiresAt, err := h.authService.IssueToken(userName)
+		if err != nil {
+			return c.String(http.StatusOK, i18n.Message(locale, err))
+		}
+
+		return c.String(http.StatusOK, i18n.T(locale, "token.issued", token, expiresAt.Format("2006-01-02")))
+
 	default:
-		return c.String(http.StatusOK, "잘못된 명령어예요. `검색`, `현황` 중 하나를 선택해주세요.")
+		return c.String(http.StatusOK, i18n.T(locale, "error.invalid_command"))
+	}
+}
+
+// commandMetric returns the English name of the subcommand for the metrics, or unknown for a mistyped one
+func commandMetric(command string) string {
+	for name, alias := range commandAliases {
+		if command == alias {
+			return name
+		}
+	}
+
+	return metrics.Unknown
+}
+
+func actionMetric(actionID string) string {
+	if slackActions[actionID] {
+		return actionID
+	}
+
+	return metrics.Unknown
+}
+
+// commandBook finds the book by the number typed in a command, or returns the message to reply with if it cannot
+func (h *SlackHandler) commandBook(ctx context.Context, value string, locale string) (models.Book, string) {
+	bookId, err := strconv.Atoi(value)
+	if err != nil {
+		return models.Book{}, i18n.T(locale, "error.book_id_number")
+	}
+
+	book, err := h.service.SearchById(ctx, bookId)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).WithField("book", bookId).Warn("Unable to find the book of the command")
+		return models.Book{}, i18n.T(locale, "error.book_not_found", bookId)
+	}
+
+	return book, ""
+}
+
+func renderSlackMessage(c echo.Context, msg slack.Message, locale string) error {
+	b, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return commandFailed(c, locale, err)
 	}
+
+	return c.JSONBlob(http.StatusOK, b)
+}
+
+// commandFailed logs the error behind a failed command and tells the user that something went wrong
+func commandFailed(c echo.Context, locale string, err error) error {
+	logging.FromContext(c.Request().Context()).WithError(err).Error("Slack command failed")
+	return c.String(http.StatusOK, i18n.T(locale, "error.server"))
+}
+
+// invalidSignature rejects a request that Slack did not sign. The error is only logged,
+// as it contains the signature expected for the body, which would let the caller forge the request.
+func invalidSignature(ctx context.Context, err error) error {
+	logging.FromContext(ctx).WithError(err).Warn("Invalid Slack signature")
+	return echo.NewHTTPError(http.StatusUnauthorized, "Invalid signature")
 }
 
+// HandleActions handles the buttons and modals. Like the commands, the request must be signed by Slack,
+// as the actions are taken as the user in the payload.
 func (h *SlackHandler) HandleActions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	verifier, err := slack.NewSecretsVerifier(c.Request().Header, h.SigningSecret)
+	if err != nil {
+		// 서명 헤더가 없거나 오래된 요청
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	c.Request().Body = ioutil.NopCloser(io.TeeReader(c.Request().Body, &verifier))
+	rawPayload := c.Request().FormValue("payload")
+	if err = verifier.Ensure(); err != nil {
+		return invalidSignature(ctx, err)
+	}
+
 	var payload slack.InteractionCallback
-	err := json.Unmarshal([]byte(c.Request().FormValue("payload")), &payload)
+	err = json.Unmarshal([]byte(rawPayload), &payload)
 	if err != nil {
-		fmt.Printf("Could not parse action response JSON: %v\n", err)
+		logging.FromContext(ctx).WithError(err).Warn("Unable to parse the Slack action payload")
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid payload")
 	}
+	locale := h.locales.Locale(ctx, payload.User.ID, payload.User.Name)
 
 	switch payload.Type {
 	case slack.InteractionTypeBlockActions:
 		for _, blockAction := range payload.ActionCallback.BlockActions {
+			metrics.SlackActions.WithLabelValues(actionMetric(blockAction.ActionID)).Inc()
 			switch blockAction.ActionID {
 			case utils.BorrowThisBook:
 				book_id, err := strconv.Atoi(blockAction.Value)
 				if err != nil {
-					h.client.PostEphemeral(payload.Channel.ID, payload.User.ID, slack.MsgOptionText("서버 오류가 발생했어요. :( 나중에 다시 시도하세요.", false))
+					h.actionFailed(ctx, payload, locale, err)
 					break
 				}
-				book, err := h.service.SearchById(book_id - 1)
+				book, err := h.service.SearchById(ctx, book_id)
 				if err != nil {
-					h.client.PostEphemeral(payload.Channel.ID, payload.User.ID, slack.MsgOptionText("서버 오류가 발생했어요. :( 나중에 다시 시도하세요.", false))
+					h.actionFailed(ctx, payload, locale, err)
 					break
 				}
 
-				book, err = h.service.Borrow(book, payload.User.Name)
+				book, err = h.service.Borrow(ctx, book, payload.User.Name)
 				if err != nil {
-					_, err = h.client.PostEphemeral(payload.Channel.ID, payload.User.ID, slack.MsgOptionText(err.Error(), false))
+					h.postEphemeral(ctx, payload, slack.MsgOptionText(i18n.Message(locale, err), false))
 					break
 				}
 
-				msg := views.RenderBorrowResult(book)
-				h.client.PostEphemeral(payload.Channel.ID, payload.User.ID, slack.MsgOptionBlocks(msg.Blocks.BlockSet...))
+				msg := views.RenderBorrowResult(book, locale)
+				h.postEphemeral(ctx, payload, slack.MsgOptionBlocks(msg.Blocks.BlockSet...))
 
 			case utils.ReturnThisBook:
 				book_id, err := strconv.Atoi(blockAction.Value)
 				if err != nil {
-					h.client.PostEphemeral(payload.Channel.ID, payload.User.ID, slack.MsgOptionText("서버 오류가 발생했어요. :( 나중에 다시 시도하세요.", false))
+					h.actionFailed(ctx, payload, locale, err)
 					break
 				}
-				book, err := h.service.SearchById(book_id - 1)
+				book, err := h.service.SearchById(ctx, book_id)
 				if err != nil {
-					h.client.PostEphemeral(payload.Channel.ID, payload.User.ID, slack.MsgOptionText("서버 오류가 발생했어요. :( 나중에 다시 시도하세요.", false))
+					h.actionFailed(ctx, payload, locale, err)
 					break
 				}
 
-				book, err = h.service.Return(book, payload.User.Name)
+				book, err = h.service.Return(ctx, book, payload.User.Name)
 				if err != nil {
-					_, err = h.client.PostEphemeral(payload.Channel.ID, payload.User.ID, slack.MsgOptionText(err.Error(), false))
+					h.postEphemeral(ctx, payload, slack.MsgOptionText(i18n.Message(locale, err), false))
 					break
 				}
 
-				msg := views.RenderReturnResult(book)
-				h.client.PostEphemeral(payload.Channel.ID, payload.User.ID, slack.MsgOptionBlocks(msg.Blocks.BlockSet...))
+				msg := views.RenderReturnResult(book, locale)
+				h.postEphemeral(ctx, payload, slack.MsgOptionBlocks(msg.Blocks.BlockSet...))
 			case utils.ExtendThisBook:
 				book_id, err := strconv.Atoi(blockAction.Value)
 				if err != nil {
-					h.client.PostEphemeral(payload.Channel.ID, payload.User.ID, slack.MsgOptionText("서버 오류가 발생했어요. :( 나중에 다시 시도하세요.", false))
+					h.actionFailed(ctx, payload, locale, err)
+					break
+				}
+				book, err := h.service.SearchById(ctx, book_id)
+				if err != nil {
+					h.actionFailed(ctx, payload, locale, err)
+					break
+				}
+
+				book, err = h.service.Extend(ctx, book, payload.User.Name)
+				if err != nil {
+					h.postEphemeral(ctx, payload, slack.MsgOptionText(i18n.Message(locale, err), false))
 					break
 				}
-				book, err := h.service.SearchById(book_id - 1)
+
+				msg := views.RenderExtendResult(book, locale)
+				h.postEphemeral(ctx, payload, slack.MsgOptionBlocks(msg.Blocks.BlockSet...))
+
+			case utils.RequestThisBook:
+				request, err := h.purchaseService.Submit(ctx, blockAction.Value, "", payload.User.Name)
 				if err != nil {
-					h.client.PostEphemeral(payload.Channel.ID, payload.User.ID, slack.MsgOptionText("서버 오류가 발생했어요. :( 나중에 다시 시도하세요.", false))
+					h.postEphemeral(ctx, payload, slack.MsgOptionText(i18n.Message(locale, err), false))
 					break
 				}
 
-				book, err = h.service.Extend(book, payload.User.Name)
+				msg := views.RenderPurchaseRequestResult(request, locale)
+				h.postEphemeral(ctx, payload, slack.MsgOptionBlocks(msg.Blocks.BlockSet...))
+
+			case utils.VoteThisRequest:
+				requestId, err := strconv.Atoi(blockAction.Value)
 				if err != nil {
-					_, err = h.client.PostEphemeral(payload.Channel.ID, payload.User.ID, slack.MsgOptionText(err.Error(), false))
+					h.actionFailed(ctx, payload, locale, err)
 					break
 				}
 
-				msg := views.RenderExtendResult(book)
-				h.client.PostEphemeral(payload.Channel.ID, payload.User.ID, slack.MsgOptionBlocks(msg.Blocks.BlockSet...))
+				request, err := h.purchaseService.Vote(ctx, requestId, payload.User.Name)
+				if err != nil {
+					h.postEphemeral(ctx, payload, slack.MsgOptionText(i18n.Message(locale, err), false))
+					break
+				}
+
+				text := i18n.T(locale, "request.voted", request.Title, request.Votes())
+				h.postEphemeral(ctx, payload, slack.MsgOptionText(text, false))
+
+			case utils.RateThisBook:
+				book_id, err := strconv.Atoi(blockAction.Value)
+				if err != nil {
+					h.actionFailed(ctx, payload, locale, err)
+					break
+				}
+				book, err := h.service.SearchById(ctx, book_id)
+				if err != nil {
+					h.actionFailed(ctx, payload, locale, err)
+					break
+				}
+
+				if _, err := h.client.OpenViewContext(ctx, payload.TriggerID, views.RenderRateModal(book, locale)); err != nil {
+					actionLogger(ctx, payload).WithError(err).Error("Unable to open the rating modal")
+				}
+			}
+		}
+
+	case slack.InteractionTypeViewSubmission:
+		metrics.SlackActions.WithLabelValues(actionMetric(payload.View.CallbackID)).Inc()
+		switch payload.View.CallbackID {
+		case utils.RateBookModal:
+			book_id, err := strconv.Atoi(payload.View.PrivateMetadata)
+			if err != nil {
+				actionLogger(ctx, payload).WithError(err).Warn("Invalid book ID in the rating modal")
+				break
+			}
+
+			values := payload.View.State.Values
+			rating, err := strconv.Atoi(values[utils.RatingInput][utils.RatingInput].SelectedOption.Value)
+			if err != nil {
+				actionLogger(ctx, payload).WithError(err).Warn("Invalid rating in the rating modal")
+				break
+			}
+			comment := values[utils.CommentInput][utils.CommentInput].Value
+
+			text := i18n.T(locale, "rate.thanks")
+			if _, err := h.reviewService.Rate(ctx, book_id, payload.User.Name, rating, comment); err != nil {
+				text = i18n.Message(locale, err)
+			}
+			if _, _, err := h.client.PostMessageContext(ctx, payload.User.ID, slack.MsgOptionText(text, false)); err != nil {
+				actionLogger(ctx, payload).WithError(err).Error("Unable to send the result of the rating")
 			}
 		}
 	}
 
 	return c.String(http.StatusOK, "")
 }
+
+// postEphemeral shows the message only to the user who acted, and logs the error if Slack does not take it
+func (h *SlackHandler) postEphemeral(ctx context.Context, payload slack.InteractionCallback, options ...slack.MsgOption) {
+	if _, err := h.client.PostEphemeralContext(ctx, payload.Channel.ID, payload.User.ID, options...); err != nil {
+		actionLogger(ctx, payload).WithError(err).Error("Unable to post an ephemeral message")
+	}
+}
+
+// actionFailed logs the error behind a failed action and tells the user that something went wrong
+func (h *SlackHandler) actionFailed(ctx context.Context, payload slack.InteractionCallback, locale string, err error) {
+	actionLogger(ctx, payload).WithError(err).Error("Slack action failed")
+	h.postEphemeral(ctx, payload, slack.MsgOptionText(i18n.T(locale, "error.server"), false))
+}
+
+func actionLogger(ctx context.Context, payload slack.InteractionCallback) *logrus.Entry {
+	return logging.FromContext(ctx).WithFields(logrus.Fields{"user": payload.User.Name, "channel": payload.Channel.ID})
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/harrydrippin/go-spreadsheet-library/logging"
+	"github.com/harrydrippin/go-spreadsheet-library/tracing"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// Trace starts a span for every request, named after the echo route, which the spans of the services and the
+// Sheets API calls become the children of. It continues the trace of the caller if the request has a traceparent header.
+// It should come after RequestLogger, so that the log of the request carries the trace ID.
+func Trace(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		request := c.Request()
+		ctx := otel.GetTextMapPropagator().Extract(request.Context(), propagation.HeaderCarrier(request.Header))
+		ctx, span := tracing.Start(ctx, request.Method+" "+c.Path(),
+			semconv.HTTPMethodKey.String(request.Method),
+			semconv.HTTPRouteKey.String(c.Path()),
+			semconv.HTTPTargetKey.String(request.URL.Path),
+			attribute.String("request_id", logging.RequestID(ctx)),
+		)
+		defer span.End()
+		c.SetRequest(request.WithContext(ctx))
+
+		// 응답의 상태 코드를 스팬에 남길 수 있도록 오류 처리를 먼저 실행함
+		if err := next(c); err != nil {
+			span.RecordError(err)
+			c.Error(err)
+		}
+
+		status := c.Response().Status
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,410 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+	views "github.com/harrydrippin/go-spreadsheet-library/view"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+const (
+	tokenCookieName = "library_token"
+	csrfCookieName  = "library_csrf"
+)
+
+// WebHandler serves the labels and the mobile pages opened by scanning them.
+// Borrowing, returning and stocktaking on the pages need a sign-in with the API token, like the admin console,
+// as anyone who can open the page could otherwise act as anyone.
+type WebHandler struct {
+	baseURL          string
+	fontPath         string
+	service          services.LibraryUsecase
+	stocktakeService services.StocktakeUsecase
+	authService      services.AuthUsecase
+}
+
+func NewWebHandler(service services.LibraryUsecase, stocktakeService services.StocktakeUsecase, authService services.AuthUsecase, config utils.Config) *WebHandler {
+	return &WebHandler{
+		baseURL:          strings.TrimRight(config.ServerBaseURL, "/"),
+		fontPath:         config.PDFFontPath,
+		service:          service,
+		stocktakeService: stocktakeService,
+		authService:      authService,
+	}
+}
+
+func (h *WebHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/books/:id/qrcode", h.QRCode)
+	e.GET("/labels", h.Labels)
+	e.GET("/api/labels.pdf", h.LabelPDF)
+	e.GET("/api/catalog.pdf", h.CatalogPDF)
+
+	// QR 코드는 다른 앱에서 열리므로 로그인 쿠키가 전달되도록 SameSite는 Lax로 두고, 폼은 CSRF 토큰으로 보호함
+	csrf := middleware.CSRFWithConfig(middleware.CSRFConfig{
+		TokenLookup:    "form:_csrf",
+		CookieName:     csrfCookieName,
+		CookiePath:     "/",
+		CookieHTTPOnly: true,
+		CookieSameSite: http.SameSiteLaxMode,
+	})
+	e.GET("/login", h.LoginPage, csrf)
+	e.POST("/login", h.Login, csrf)
+	e.POST("/logout", h.Logout, csrf)
+	e.GET("/scan/:id", h.Scan, h.Identify, csrf)
+	e.POST("/scan/:id", h.ScanAction, h.Identify, csrf)
+	e.GET("/stocktake", h.Stocktake, h.Identify, csrf)
+	e.POST("/stocktake", h.StocktakeAction, h.Identify, csrf)
+}
+
+// Identify is a middleware that recognizes the user signed in with an API token. The pages can be viewed without one.
+func (h *WebHandler) Identify(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cookie, err := c.Cookie(tokenCookieName)
+		if err != nil {
+			return next(c)
+		}
+
+		identity, err := h.authService.Authenticate(cookie.Value)
+		if err != nil {
+			// 만료되었거나 폐기된 토큰은 지워서 다시 로그인하게 함
+			h.setTokenCookie(c, "", -1)
+			return next(c)
+		}
+
+		c.Set(identityKey, identity)
+		return next(c)
+	}
+}
+
+func (h *WebHandler) LoginPage(c echo.Context) error {
+	return h.renderLoginPage(c, http.StatusOK, "")
+}
+
+func (h *WebHandler) Login(c echo.Context) error {
+	locale := requestLocale(c)
+	token := strings.TrimSpace(c.FormValue("token"))
+	if _, err := h.authService.Authenticate(token); err != nil {
+		return h.renderLoginPage(c, http.StatusUnauthorized, i18n.Message(locale, err))
+	}
+
+	// 관리 콘솔처럼 브라우저를 닫으면 로그아웃되도록 만료 시각 없이 저장함
+	h.setTokenCookie(c, token, 0)
+	return c.Redirect(http.StatusSeeOther, nextPath(c.FormValue("next")))
+}
+
+func (h *WebHandler) Logout(c echo.Context) error {
+	h.setTokenCookie(c, "", -1)
+	return c.Redirect(http.StatusSeeOther, nextPath(c.FormValue("next")))
+}
+
+func (h *WebHandler) renderLoginPage(c echo.Context, status int, message string) error {
+	page := views.LoginPage{
+		Locale: requestLocale(c),
+		Next:   nextPath(c.FormValue("next")),
+		CSRF:   csrfToken(c),
+		Error:  message,
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	c.Response().WriteHeader(status)
+	return views.RenderLoginPage(c.Response(), page)
+}
+
+func (h *WebHandler) setTokenCookie(c echo.Context, token string, maxAge int) {
+	c.SetCookie(&http.Cookie{
+		Name:     tokenCookieName,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   maxAge,
+		Secure:   c.Scheme() == "https",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// signedInUser returns the user signed in on the page, or an error page asking to sign in.
+// Borrowing and returning need the write scope, like the API.
+func signedInUser(c echo.Context) (string, error) {
+	identity := identityOf(c)
+	if identity.User == "" {
+		return "", i18n.Wrap(models.ErrUnauthenticated, "web.login_required")
+	}
+	if !identity.HasScope(models.ScopeWrite) {
+		return "", i18n.Wrap(models.ErrForbidden, "error.insufficient_scope", models.ScopeWrite)
+	}
+
+	return identity.User, nil
+}
+
+// nextPath returns the page to go back to after signing in, which must be on this server
+func nextPath(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		return "/"
+	}
+
+	return next
+}
+
+// csrfToken returns the token the forms of the page must send back
+func csrfToken(c echo.Context) string {
+	token, _ := c.Get(middleware.DefaultCSRFConfig.ContextKey).(string)
+	return token
+}
+
+// scanURL is the URL encoded into the QR code of the given book.
+func (h *WebHandler) scanURL(id int) string {
+	return fmt.Sprintf("%s/scan/%d", h.baseURL, id)
+}
+
+func (h *WebHandler) findBook(c echo.Context) (models.Book, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return models.Book{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid book id")
+	}
+
+	book, err := h.service.SearchById(c.Request().Context(), id)
+	if err != nil {
+		return models.Book{}, err
+	}
+
+	return book, nil
+}
+
+func (h *WebHandler) QRCode(c echo.Context) error {
+	book, err := h.findBook(c)
+	if err != nil {
+		return err
+	}
+
+	size := 256
+	if c.QueryParam("size") != "" {
+		size, err = strconv.Atoi(c.QueryParam("size"))
+		if err != nil || size < 64 || size > 2048 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Size must be between 64 and 2048")
+		}
+	}
+
+	switch c.QueryParam("format") {
+	case "", "png":
+		png, err := views.RenderQRCodePNG(h.scanURL(book.ID), size)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		return c.Blob(http.StatusOK, "image/png", png)
+	case "svg":
+		svg, err := views.RenderQRCodeSVG(h.scanURL(book.ID), size)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		return c.Blob(http.StatusOK, "image/svg+xml", []byte(svg))
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "Format must be png or svg")
+	}
+}
+
+// selectedBooks returns the books listed in the "ids" query parameter, or every book if it is empty.
+func (h *WebHandler) selectedBooks(c echo.Context) ([]models.Book, error) {
+	books, err := h.service.List(c.Request().Context())
+	if err != nil {
+		return nil, err
+	}
+
+	if c.QueryParam("ids") != "" {
+		books, err = filterBooksById(books, c.QueryParam("ids"))
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+	}
+
+	return books, nil
+}
+
+func (h *WebHandler) Labels(c echo.Context) error {
+	books, err := h.selectedBooks(c)
+	if err != nil {
+		return err
+	}
+
+	labels := make([]views.Label, 0, len(books))
+	for _, book := range books {
+		svg, err := views.RenderQRCodeSVG(h.scanURL(book.ID), 256)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		labels = append(labels, views.Label{Book: book, URL: h.scanURL(book.ID), QRCode: template.HTML(svg)})
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return views.RenderLabelSheet(c.Response(), views.LabelSheet{Locale: requestLocale(c), Labels: labels})
+}
+
+func (h *WebHandler) LabelPDF(c echo.Context) error {
+	books, err := h.selectedBooks(c)
+	if err != nil {
+		return err
+	}
+
+	labels := make([]views.PDFLabel, 0, len(books))
+	for _, book := range books {
+		png, err := views.RenderQRCodePNG(h.scanURL(book.ID), 256)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		labels = append(labels, views.PDFLabel{Book: book, QRCode: png})
+	}
+
+	buffer := bytes.Buffer{}
+	if err := views.RenderLabelPDF(&buffer, labels, h.fontPath, requestLocale(c)); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.Blob(http.StatusOK, "application/pdf", buffer.Bytes())
+}
+
+func (h *WebHandler) CatalogPDF(c echo.Context) error {
+	books, err := h.service.List(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	buffer := bytes.Buffer{}
+	if err := views.RenderCatalogPDF(&buffer, books, h.fontPath, requestLocale(c)); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.Blob(http.StatusOK, "application/pdf", buffer.Bytes())
+}
+
+func (h *WebHandler) Scan(c echo.Context) error {
+	book, err := h.findBook(c)
+	if err != nil {
+		return err
+	}
+
+	return h.renderScanPage(c, views.ScanPage{Book: book})
+}
+
+func (h *WebHandler) ScanAction(c echo.Context) error {
+	ctx := c.Request().Context()
+	book, err := h.findBook(c)
+	if err != nil {
+		return err
+	}
+
+	locale := requestLocale(c)
+	user, err := signedInUser(c)
+	if err != nil {
+		return h.renderScanPage(c, views.ScanPage{Book: book, Error: i18n.Message(locale, err)})
+	}
+
+	var message string
+	if book.Status == models.StatusInOffice {
+		book, err = h.service.Borrow(ctx, book, user)
+		message = i18n.T(locale, "web.borrowed", book.DueDate)
+	} else {
+		book, err = h.service.Return(ctx, book, user)
+		message = i18n.T(locale, "web.returned")
+	}
+
+	if err != nil {
+		// 실패한 경우 최신 상태를 다시 보여줌
+		book, _ = h.findBook(c)
+		return h.renderScanPage(c, views.ScanPage{Book: book, Error: i18n.Message(locale, err)})
+	}
+
+	return h.renderScanPage(c, views.ScanPage{Book: book, Message: message})
+}
+
+func (h *WebHandler) renderScanPage(c echo.Context, page views.ScanPage) error {
+	// 재고 조사 중에는 스캔한 책을 바로 확인 처리할 수 있도록 함
+	if _, err := h.stocktakeService.Current(c.Request().Context()); err == nil {
+		page.Stocktake = true
+	}
+	page.Locale = requestLocale(c)
+	page.User = identityOf(c).User
+	page.CSRF = csrfToken(c)
+	page.Path = c.Request().URL.Path
+
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return views.RenderScanPage(c.Response(), page)
+}
+
+func (h *WebHandler) Stocktake(c echo.Context) error {
+	return h.renderStocktakePage(c, views.StocktakePage{})
+}
+
+func (h *WebHandler) StocktakeAction(c echo.Context) error {
+	position := strings.TrimSpace(c.FormValue("position"))
+	locale := requestLocale(c)
+	page := views.StocktakePage{Position: position}
+
+	user, err := signedInUser(c)
+	if err != nil {
+		page.Error = i18n.Message(locale, err)
+		return h.renderStocktakePage(c, page)
+	}
+
+	bookId, err := strconv.Atoi(strings.TrimSpace(c.FormValue("book_id")))
+	if err != nil {
+		page.Error = i18n.T(locale, "error.book_id_number")
+		return h.renderStocktakePage(c, page)
+	}
+
+	book, err := h.stocktakeService.MarkSeen(c.Request().Context(), bookId, position, user)
+	if err != nil {
+		page.Error = i18n.Message(locale, err)
+		return h.renderStocktakePage(c, page)
+	}
+
+	page.Message = i18n.T(locale, "web.seen", book.ID, book.Title)
+	return h.renderStocktakePage(c, page)
+}
+
+func (h *WebHandler) renderStocktakePage(c echo.Context, page views.StocktakePage) error {
+	ctx := c.Request().Context()
+	page.Locale = requestLocale(c)
+	page.User = identityOf(c).User
+	page.CSRF = csrfToken(c)
+	session, err := h.stocktakeService.Current(ctx)
+	if err == nil {
+		page.Session = session
+		page.Report, err = h.stocktakeService.Report(ctx)
+	}
+	if err != nil && page.Error == "" {
+		page.Error = i18n.Message(page.Locale, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	return views.RenderStocktakePage(c.Response(), page)
+}
+
+// filterBooksById returns the books whose ID is in the comma-separated list of ids.
+func filterBooksById(books []models.Book, ids string) ([]models.Book, error) {
+	wanted := make(map[int]bool)
+	for _, raw := range strings.Split(ids, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("invalid book id %q", raw)
+		}
+		wanted[id] = true
+	}
+
+	result := []models.Book{}
+	for _, book := range books {
+		if wanted[book.ID] {
+			result = append(result, book)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler struct {
+	service services.WebhookUsecase
+}
+
+func NewWebhookHandler(service services.WebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+func (h *WebhookHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/v2/webhooks", h.List, requireScope(models.ScopeAdmin))
+	e.GET("/api/v2/webhooks/deliveries", h.Deliveries, requireScope(models.ScopeAdmin))
+	e.POST("/api/v2/webhooks/:name/test", h.Test, requireScope(models.ScopeAdmin))
+}
+
+func (h *WebhookHandler) List(c echo.Context) error {
+	webhooks, err := h.service.Webhooks(c.Request().Context(), identityOf(c).User)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, webhooks)
+}
+
+// Deliveries returns the delivery log, newest first, optionally of a single webhook
+func (h *WebhookHandler) Deliveries(c echo.Context) error {
+	deliveries, err := h.service.Deliveries(c.Request().Context(), c.QueryParam("webhook"), identityOf(c).User)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+// Test sends a ping to the webhook and returns how it went, even if the webhook rejected it
+func (h *WebhookHandler) Test(c echo.Context) error {
+	delivery, err := h.service.Test(c.Request().Context(), c.Param("name"), identityOf(c).User)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, delivery)
+}
@@ -0,0 +1,248 @@
+package i18n
+
+// english is the message catalog of the English locale
+var english = map[string]string{
+	// Errors
+	"error.server":                     "Something went wrong on the server. :( Please try again later.",
+	"error.invalid_command":            "Unknown command. Try one of `search`, `status`, `recommend`, `request`, `requests`, `language` or `token`.",
+	"error.usage":                      "Invalid command.\nUsage: %s",
+	"error.book_id_number":             "Please enter the book number as a number.",
+	"error.request_id_number":          "Please enter the request number as a number.",
+	"error.user_required":              "Please enter your Slack user name.",
+	"error.unknown_status":             "Unknown status. Please enter one of `lost`, `damaged`, `repairing`, `withdrawn` or `available`.",
+	"error.already_borrowed":           "This book is already borrowed. Please check again.",
+	"error.not_borrowed":               "This book is not borrowed! Please check again.",
+	"error.not_borrower":               "This book was not borrowed by @%s. Please check again!",
+	"error.not_extendable":             "This book is not on loan. Please check again.",
+	"error.role_required":              "Only users with the %s role or higher can do this.",
+	"error.invalid_date":               "Please enter the date as YYYY-MM-DD. (Entered: %s)",
+	"error.delete_borrowed":            "The book is borrowed by @%s and cannot be deleted. Please return it first.",
+	"error.book_title_required":        "Please enter the title of the book.",
+	"error.unknown_field":              "Please enter one of `title`, `author`, `publisher` or `position` to edit.",
+	"error.invalid_status_transition":  "A book that is '%s' cannot be changed to '%s'.",
+	"error.request_title_required":     "Please enter the title of the book to request.",
+	"error.request_in_library":         "This book is already in the library. Try `/library search`!",
+	"error.request_duplicate":          "This book has already been requested. Please vote for request #%d!",
+	"error.request_closed":             "This request has already been handled.",
+	"error.request_voted":              "You have already requested or voted for this book.",
+	"error.request_invalid_transition": "Only requests that are '%s' can be marked '%s'. (current: %s)",
+	"error.rating_range":               "Please rate between 1 and 5.",
+	"error.comment_too_long":           "Please keep your comment within 300 characters.",
+	"error.no_stocktake":               "There is no stocktake in progress.",
+	"error.stocktake_in_progress":      "Stocktake #%d is already in progress.",
+	"error.book_not_found":             "Book #%d was not found.",
+	"error.unsupported_locale":         "Unsupported language: %s. Please enter `ko` or `en`.",
+	"error.auth_disabled":              "API authentication is not configured. Please contact an admin.",
+	"error.token_required":             "An API token is required. Get one with `/library token` and send it in the Authorization header.",
+	"error.invalid_token":              "The API token is invalid or expired. Please get a new one with `/library token`.",
+	"error.insufficient_scope":         "A token with the '%s' scope is required.",
+	"error.act_for_others":             "Only admins can act on behalf of other users.",
+	"error.query_too_deep":             "The query is too deep. (depth %d, up to %d)",
+	"error.query_too_complex":          "The query is too complex. Please ask only for the fields you need. (cost %d, up to %d)",
+	"error.webhook_not_found":          "There is no webhook named %s.",
+
+	// Command usage
+	"usage.search":       "/library search `<keyword>`",
+	"usage.status":       "/library status",
+	"usage.recommend":    "/library recommend",
+	"usage.request":      "/library request `<title>` or /library request `<title> / <author>`",
+	"usage.requests":     "/library requests",
+	"usage.approve":      "/library approve `<request number>`",
+	"usage.reject":       "/library reject `<request number>` `[reason]`",
+	"usage.purchased":    "/library purchased `<request number>`",
+	"usage.set_status":   "/library set-status `<book number>` `<lost|damaged|repairing|withdrawn|available>`",
+	"usage.lost":         "/library lost",
+	"usage.stocktake":    "/library stocktake `<start|report|close>`",
+	"usage.seen":         "/library seen `<book number>` `[found at]`",
+	"usage.language":     "/library language `<ko|en>`",
+	"usage.token":        "/library token",
+	"usage.force_return": "/library force-return `<book number>`",
+	"usage.due_date":     "/library due-date `<book number>` `<YYYY-MM-DD>`",
+	"usage.edit":         "/library edit `<book number>` `<title|author|publisher|position>` `<value>`",
+	"usage.delete":       "/library delete `<book number>`",
+	"usage.role":         "/library role",
+
+	// Books
+	"book.info":             ">*%s*\n>by %s, %s\n>Status: %s",
+	"book.status_due":       "*%s* (%s, due %s)",
+	"book.status_extended":  "*Extended* (%s, due %s)",
+	"book.status_changed":   "Changed #%d *%s* to '%s'.",
+	"book.force_returned":   "Returned #%d *%s* on behalf of @%s.",
+	"book.due_date_changed": "Changed the due date of #%d *%s* to %s.",
+	"book.edited":           "Updated the details of #%d *%s*.\n>by %s, %s\n>Location: %s",
+	"book.deleted":          "Deleted #%d *%s* from the catalog.",
+	"role.current":          "@%s, your role is *%s*.",
+	"button.borrow":         "Borrow",
+	"button.return":         "Return",
+	"button.extend":         "Extend",
+	"button.rate":           "Rate",
+	"button.request":        "Request purchase",
+	"button.vote":           "Vote",
+	"list.more":             ">and %d more",
+
+	// Search, borrow, return, extend, status
+	"search.header":      "Found *%[2]d* result(s) for *%[1]s*.",
+	"search.empty":       "Try a more general keyword, or look it up in the <%s|Spreadsheet>.\nIf the library doesn't have the book, you can request a purchase.",
+	"search.too_many":    "Only up to 5 results are shown. Please look up the rest in the <%s|Spreadsheet>.",
+	"borrow.done":        "You've borrowed the book! Please check the details below.\nPlease return it on time, or extend the loan if you need more time :)",
+	"return.done":        "You've returned the book. Thanks for using the library!",
+	"return.rate_prompt": "How was this book? A rating and a short review help others a lot.",
+	"extend.done":        "Your loan has been extended. Please check the new due date and return it on time!",
+	"status.header":      "@%s has borrowed %d book(s).",
+	"status.empty":       "To borrow a book, try `/library search <part of the title>`!",
+	"status.lost":        "*Lost* (please return it if you find it)",
+
+	// Reviews
+	"review.summary":           "\n>Rating: ★ %.1f (%d)",
+	"review.recent":            "\n>_\"%s\"_ - %s",
+	"rate.book_info":           "*%s*\nby %s, %s",
+	"rate.rating_placeholder":  "Choose a rating",
+	"rate.rating":              "Rating",
+	"rate.comment_placeholder": "Who would you recommend it to?",
+	"rate.comment":             "Short review",
+	"rate.title":               "Rate this book",
+	"rate.submit":              "Submit",
+	"rate.cancel":              "Cancel",
+	"rate.thanks":              "Thanks for your review! It will help others pick their next book :)",
+
+	// Purchase requests
+	"request.unknown_author": "Unknown author",
+	"request.info":           ">*%d. %s*\n>%s, requested by @%s (%s)\n>%d vote(s), status: *%s*",
+	"request.done":           "Your purchase request has been submitted! Requests with more votes are purchased first.\nWe'll let you know when the book arrives :)",
+	"request.list_header":    "There are *%d* open purchase request(s).",
+	"request.list_empty":     "If there's a book you'd like to read, request it with `/library request <title>`!",
+	"request.list_too_many":  "Only the 10 most voted requests are shown.",
+	"request.voted":          "You voted for *%s*! %d people want this book so far.",
+	"request.status_changed": "Changed request #%d (%s) to '%s'.",
+	"notify.request_stocked": "*%s*, which you requested, has arrived at the library! (Location: %s)\nBorrow it with `/library search %s`.",
+
+	// Recommendations
+	"recommendation.header":             "Books recommended for @%s.",
+	"recommendation.empty":              "We couldn't find books to recommend for @%s yet. Borrow more books and we'll have some for you!",
+	"recommendation.reason.co_borrowed": "Readers of the books you read also read this.",
+	"recommendation.reason.author":      "By %s, an author you read often.",
+	"recommendation.reason.category":    "From a section you read often (%s).",
+	"recommendation.reason.popular":     "A popular book read by %s people.",
+
+	// Stocktake, lost books
+	"stocktake.opened":            "Started stocktake #%d! Check books on the shelves with `/library seen <book number> [found at]`, or scan their QR codes.",
+	"stocktake.seen":              "Checked #%d *%s*.",
+	"stocktake.report_interim":    "Interim results of stocktake #%d. (started %s)",
+	"stocktake.report_closed":     "Stocktake #%d is closed. (%s ~ %s)",
+	"stocktake.report_progress":   "Checked *%[2]d* of %[1]d books.",
+	"stocktake.missing":           "*Available but not found (%d)*",
+	"stocktake.borrowed_on_shelf": "*Borrowed but found on the shelf (%d)*",
+	"stocktake.misplaced":         "*Found in another place (%d)*",
+	"stocktake.misplaced_book":    ">#%d *%s* (%s → found at %s)",
+	"lost.header":                 "*%d* book(s) are lost.",
+	"lost.borrower":               "*@%s* (%d)",
+	"lost.shelf":                  "*Lost from the shelf* (%d)",
+
+	// Language
+	"language.changed": "From now on, messages will be in English.",
+
+	// API tokens
+	"token.issued": "Here is your API token. Keep it to yourself!\n```%s```\nIt is valid until %s. Send it in the `Authorization: Bearer <token>` header of your requests.",
+
+	// Label and catalog PDFs
+	"labels.title":             "Book labels",
+	"catalog.title":            "Catalog",
+	"catalog.summary":          "%d books as of %s",
+	"catalog.column_title":     "Title",
+	"catalog.column_author":    "Author",
+	"catalog.column_publisher": "Publisher",
+	"catalog.column_status":    "Status",
+	"catalog.no_position":      "(No location)",
+	"catalog.position":         "%s (%d)",
+
+	// Public catalog page
+	"catalog.search_placeholder":  "Search by title",
+	"catalog.search":              "Search",
+	"catalog.filter_all":          "All books",
+	"catalog.filter_available":    "Available",
+	"catalog.filter_borrowed":     "Borrowed",
+	"catalog.filter_any_position": "Any location",
+	"catalog.count":               "%d books",
+	"catalog.empty":               "No books match your search.",
+	"catalog.available":           "Available",
+	"catalog.borrowed":            "Borrowed",
+	"catalog.borrowed_until":      "Borrowed (due back %s)",
+	"catalog.rating":              "★ %.1f (%d)",
+	"catalog.book_position":       "Location: %s",
+	"catalog.reviews":             "Ratings and reviews",
+	"catalog.no_reviews":          "No reviews yet.",
+	"catalog.back":                "Back to the catalog",
+
+	// Web pages
+	"web.book_author":        "by %s, %s",
+	"web.book_status":        "Status:",
+	"web.book_due":           "due %s",
+	"web.signed_in_as":       "Signed in as @%s.",
+	"web.logout":             "Sign out",
+	"web.login_title":        "Sign in to the library",
+	"web.login_help":         "Enter the token you got with `/library token` in Slack. You stay signed in on this device until the browser is closed.",
+	"web.login_required":     "Please sign in first.",
+	"web.login_to_borrow":    "Sign in to borrow or return",
+	"web.login_to_stocktake": "Sign in to take stock",
+	"web.borrowed":           "You've borrowed the book! Please return it by %s.",
+	"web.returned":           "You've returned the book. Thanks for using the library!",
+	"web.seen":               "Checked #%d %s.",
+	"web.stocktake_seen":     "Stocktake: found on the shelf",
+	"web.stocktake_title":    "Stocktake",
+	"web.stocktake_progress": "Stocktake #%d in progress (started %s, %d / %d checked)",
+	"web.book_id":            "Book number",
+	"web.seen_position":      "Found at (leave empty if same as the catalog)",
+	"web.confirm":            "Check",
+	"web.stocktake_none":     "There is no stocktake in progress. An admin can start one with `/library stocktake start` in Slack.",
+
+	// Admin console
+	"admin.title":            "Library admin",
+	"admin.nav_catalog":      "Catalog",
+	"admin.nav_loans":        "Loans",
+	"admin.nav_overdue":      "Overdue",
+	"admin.nav_audit":        "Audit history",
+	"admin.nav_new_book":     "Add a book",
+	"admin.signed_in":        "@%s (%s)",
+	"admin.logout":           "Sign out",
+	"admin.login_title":      "Sign in to the admin console",
+	"admin.login_help":       "Enter the token you got with `/library token` in Slack. Only librarians and admins can sign in.",
+	"admin.token":            "API token",
+	"admin.login":            "Sign in",
+	"admin.librarian_only":   "Only librarians and admins can use the admin console.",
+	"admin.search":           "Search",
+	"admin.include_archived": "Include lost, damaged and withdrawn books",
+	"admin.books_count":      "%d book(s)",
+	"admin.column_id":        "#",
+	"admin.column_title":     "Title",
+	"admin.column_author":    "Author",
+	"admin.column_publisher": "Publisher",
+	"admin.column_position":  "Location",
+	"admin.column_status":    "Status",
+	"admin.column_borrower":  "Borrower",
+	"admin.column_due_date":  "Due date",
+	"admin.column_at":        "When",
+	"admin.column_actor":     "By",
+	"admin.column_action":    "Action",
+	"admin.column_detail":    "Detail",
+	"admin.empty":            "Nothing here.",
+	"admin.save":             "Save",
+	"admin.saved":            "Saved.",
+	"admin.loan":             "Loan",
+	"admin.borrowed_by":      "Borrowed by @%s, due %s.",
+	"admin.not_borrowed":     "Not borrowed.",
+	"admin.overdue":          "Overdue",
+	"admin.change_due_date":  "Change due date",
+	"admin.reassign":         "Reassign borrower",
+	"admin.force_return":     "Force return",
+	"admin.change_status":    "Change status",
+	"admin.archive":          "Archive (withdraw)",
+	"admin.archive_confirm":  "Archive this book as withdrawn?",
+	"audit.create":           "Book added",
+	"audit.edit":             "Details edited",
+	"audit.archive":          "Archived",
+	"audit.delete":           "Deleted",
+	"audit.status":           "Status changed",
+	"audit.force_return":     "Force returned",
+	"audit.due_date":         "Due date changed",
+	"audit.reassign":         "Borrower reassigned",
+}
@@ -0,0 +1,157 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Supported locales
+const (
+	Korean  = "ko"
+	English = "en"
+
+	DefaultLocale = Korean
+)
+
+// Locales lists every supported locale
+var Locales = []string{Korean, English}
+
+var catalogs = map[string]map[string]string{
+	Korean:  korean,
+	English: english,
+}
+
+// labeler is a value with a display label per locale, such as a book status
+type labeler interface {
+	Label(locale string) string
+}
+
+// T returns the message for the key in the locale, formatted with the args.
+// Messages missing in the locale fall back to the default locale, then to the key itself.
+// Args with a display label per locale are rendered with their label.
+func T(locale, key string, args ...interface{}) string {
+	locale = Normalize(locale)
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = catalogs[DefaultLocale][key]
+		if !ok {
+			return key
+		}
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+
+	formatArgs := make([]interface{}, len(args))
+	for i, arg := range args {
+		if l, ok := arg.(labeler); ok {
+			formatArgs[i] = l.Label(locale)
+		} else {
+			formatArgs[i] = arg
+		}
+	}
+
+	return fmt.Sprintf(message, formatArgs...)
+}
+
+// Normalize converts a language tag such as "en-US" into a supported locale, or the default locale if unsupported.
+func Normalize(tag string) string {
+	if locale, ok := Parse(tag); ok {
+		return locale
+	}
+
+	return DefaultLocale
+}
+
+// Parse converts a language tag such as "en-US" into a supported locale.
+func Parse(tag string) (string, bool) {
+	language := strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+
+	for _, locale := range Locales {
+		if language == locale {
+			return locale, true
+		}
+	}
+
+	return "", false
+}
+
+// FromAcceptLanguage picks the supported locale with the highest quality from an Accept-Language header.
+func FromAcceptLanguage(header string) string {
+	type candidate struct {
+		locale  string
+		quality float64
+	}
+
+	candidates := []candidate{}
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		locale, ok := Parse(fields[0])
+		if !ok {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			if value := strings.TrimPrefix(strings.TrimSpace(param), "q="); value != strings.TrimSpace(param) {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		candidates = append(candidates, candidate{locale, quality})
+	}
+
+	if len(candidates) == 0 {
+		return DefaultLocale
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	return candidates[0].locale
+}
+
+// Error is an error whose message is rendered from the catalog in the locale of the user who sees it.
+// Err is the underlying error, if any, so that callers can still tell what kind of failure it was.
+type Error struct {
+	Key  string
+	Args []interface{}
+	Err  error
+}
+
+// NewError returns an error rendered from the message for the key.
+func NewError(key string, args ...interface{}) error {
+	return &Error{Key: key, Args: args}
+}
+
+// Wrap returns an error rendered from the message for the key, wrapping err.
+func Wrap(err error, key string, args ...interface{}) error {
+	return &Error{Key: key, Args: args, Err: err}
+}
+
+func (e *Error) Error() string {
+	return T(DefaultLocale, e.Key, e.Args...)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Message renders the error in the locale. Errors not created by NewError are returned as they are.
+func Message(locale string, err error) string {
+	var localized *Error
+	if errors.As(err, &localized) {
+		return T(locale, localized.Key, localized.Args...)
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,248 @@
+package i18n
+
+// korean is the message catalog of the default locale
+var korean = map[string]string{
+	// 오류
+	"error.server":                     "서버 오류가 발생했어요. :( 나중에 다시 시도하세요.",
+	"error.invalid_command":            "잘못된 명령어예요. `검색`, `현황`, `추천`, `신청`, `신청목록`, `언어`, `토큰` 중 하나를 선택해주세요.",
+	"error.usage":                      "명령이 잘못되었어요.\n사용 방법: %s",
+	"error.book_id_number":             "책 번호는 숫자로 입력해주세요.",
+	"error.request_id_number":          "신청 번호는 숫자로 입력해주세요.",
+	"error.user_required":              "Slack 사용자 이름을 입력해주세요.",
+	"error.unknown_status":             "알 수 없는 상태예요. `분실`, `파손`, `수리중`, `폐기`, `사내 비치` 중 하나를 입력해주세요.",
+	"error.already_borrowed":           "이미 대출되어 있는 책이에요. 다시 확인해주세요.",
+	"error.not_borrowed":               "대출된 책이 아니에요! 다시 확인해주세요.",
+	"error.not_borrower":               "@%s 님이 대출하신 책이 아니에요. 다시 확인해주세요!",
+	"error.not_extendable":             "이 책은 대출된 상태가 아니에요. 다시 확인해주세요.",
+	"error.role_required":              "%s 이상의 권한이 있어야 처리할 수 있어요.",
+	"error.invalid_date":               "날짜는 YYYY-MM-DD 형식으로 입력해주세요. (입력: %s)",
+	"error.delete_borrowed":            "@%s 님이 대출 중인 책은 삭제할 수 없어요. 먼저 반납 처리해주세요.",
+	"error.book_title_required":        "책 제목을 입력해주세요.",
+	"error.unknown_field":              "수정할 항목으로 `제목`, `저자`, `출판사`, `위치` 중 하나를 입력해주세요.",
+	"error.invalid_status_transition":  "'%s' 상태인 책은 '%s' 상태로 변경할 수 없어요.",
+	"error.request_title_required":     "신청할 책의 제목을 입력해주세요.",
+	"error.request_in_library":         "이미 도서관에 있는 책이에요. `/도서관 검색` 으로 찾아보세요!",
+	"error.request_duplicate":          "이미 신청된 책이에요. 신청 번호 %d번을 추천해주세요!",
+	"error.request_closed":             "이미 처리된 신청이에요.",
+	"error.request_voted":              "이미 신청하거나 추천하신 책이에요.",
+	"error.request_invalid_transition": "'%s' 상태인 신청만 '%s' 처리할 수 있어요. (현재: %s)",
+	"error.rating_range":               "평점은 1점에서 5점 사이로 남겨주세요.",
+	"error.comment_too_long":           "한 줄 평은 300자 이내로 남겨주세요.",
+	"error.no_stocktake":               "진행 중인 재고 조사가 없어요.",
+	"error.stocktake_in_progress":      "이미 %d번 재고 조사가 진행 중이에요.",
+	"error.book_not_found":             "%d번 책을 찾을 수 없어요.",
+	"error.unsupported_locale":         "지원하지 않는 언어예요: %s. `ko` 또는 `en` 을 입력해주세요.",
+	"error.auth_disabled":              "API 인증이 설정되지 않았어요. 관리자에게 문의해주세요.",
+	"error.token_required":             "API 토큰이 필요해요. `/도서관 토큰` 으로 발급받아 Authorization 헤더에 넣어주세요.",
+	"error.invalid_token":              "API 토큰이 올바르지 않거나 만료되었어요. `/도서관 토큰` 으로 다시 발급받아주세요.",
+	"error.insufficient_scope":         "'%s' 권한이 있는 토큰이 필요해요.",
+	"error.act_for_others":             "다른 사용자 대신 처리하는 것은 관리자만 할 수 있어요.",
+	"error.query_too_deep":             "쿼리가 너무 깊어요. (깊이 %d, 최대 %d)",
+	"error.query_too_complex":          "쿼리가 너무 복잡해요. 필요한 필드만 요청해주세요. (비용 %d, 최대 %d)",
+	"error.webhook_not_found":          "%s 웹훅을 찾을 수 없어요.",
+
+	// 명령어 사용 방법
+	"usage.search":       "/도서관 검색 `<검색어>`",
+	"usage.status":       "/도서관 현황",
+	"usage.recommend":    "/도서관 추천",
+	"usage.request":      "/도서관 신청 `<책 제목>` 또는 /도서관 신청 `<책 제목> / <저자>`",
+	"usage.requests":     "/도서관 신청목록",
+	"usage.approve":      "/도서관 신청승인 `<신청 번호>`",
+	"usage.reject":       "/도서관 신청반려 `<신청 번호>` `[사유]`",
+	"usage.purchased":    "/도서관 구매완료 `<신청 번호>`",
+	"usage.set_status":   "/도서관 상태변경 `<책 번호>` `<분실|파손|수리중|폐기|사내 비치>`",
+	"usage.lost":         "/도서관 분실현황",
+	"usage.stocktake":    "/도서관 재고조사 `<시작|현황|종료>`",
+	"usage.seen":         "/도서관 확인 `<책 번호>` `[발견 위치]`",
+	"usage.language":     "/도서관 언어 `<ko|en>`",
+	"usage.token":        "/도서관 토큰",
+	"usage.force_return": "/도서관 강제반납 `<책 번호>`",
+	"usage.due_date":     "/도서관 기한변경 `<책 번호>` `<YYYY-MM-DD>`",
+	"usage.edit":         "/도서관 정보수정 `<책 번호>` `<제목|저자|출판사|위치>` `<내용>`",
+	"usage.delete":       "/도서관 삭제 `<책 번호>`",
+	"usage.role":         "/도서관 권한",
+
+	// 책
+	"book.info":             ">*%s*\n>%s 지음, %s\n>현재 상태: %s",
+	"book.status_due":       "*%s* (%s, %s 반납 예정)",
+	"book.status_extended":  "*연장 처리* (%s, %s 반납 예정)",
+	"book.status_changed":   "#%d *%s* 을(를) '%s' 상태로 변경했어요.",
+	"book.force_returned":   "#%d *%s* 을(를) @%s 님 대신 반납 처리했어요.",
+	"book.due_date_changed": "#%d *%s* 의 반납 기한을 %s(으)로 변경했어요.",
+	"book.edited":           "#%d *%s* 의 정보를 수정했어요.\n>%s 지음, %s\n>위치: %s",
+	"book.deleted":          "#%d *%s* 을(를) 도서 목록에서 삭제했어요.",
+	"role.current":          "@%s 님의 권한은 *%s* 이에요.",
+	"button.borrow":         "대출하기",
+	"button.return":         "반납하기",
+	"button.extend":         "연장하기",
+	"button.rate":           "평가하기",
+	"button.request":        "구매 신청하기",
+	"button.vote":           "추천하기",
+	"list.more":             ">외 %d권",
+
+	// 검색, 대출, 반납, 연장, 현황
+	"search.header":      "검색하신 *%s* 에 대한 *%d개* 의 결과가 있어요.",
+	"search.empty":       "조금 더 일반적인 키워드로 검색해보시거나, <%s|Spreadsheet>에서 직접 찾아주세요.\n도서관에 없는 책이라면 구매를 신청할 수 있어요.",
+	"search.too_many":    "검색 결과는 5개까지만 표시돼요. 그 이상은 <%s|Spreadsheet>에서 직접 찾아주세요.",
+	"borrow.done":        "대출이 완료되었어요! 아래 내용을 확인해주세요.\n꼭 기한 내에 반납해주시고, 불가피하다면 연장 신청을 부탁드려요 :)",
+	"return.done":        "반납이 완료되었어요. 이용해주셔서 감사해요!",
+	"return.rate_prompt": "이 책은 어떠셨나요? 평점과 한 줄 평을 남겨주시면 다른 분들께 큰 도움이 돼요.",
+	"extend.done":        "연장이 완료되었어요. 갱신된 기간을 확인해주시고, 기간 내에 반납해주세요!",
+	"status.header":      "@%s 님께서는 %d 권의 책을 대출하셨어요.",
+	"status.empty":       "대출하시려면, `/도서관 검색 <책 이름의 일부>` 를 이용해보세요!",
+	"status.lost":        "*분실* (찾으셨다면 반납해주세요)",
+
+	// 평가
+	"review.summary":           "\n>평점: ★ %.1f (%d명)",
+	"review.recent":            "\n>_\"%s\"_ - %s",
+	"rate.book_info":           "*%s*\n%s 지음, %s",
+	"rate.rating_placeholder":  "평점을 선택해주세요",
+	"rate.rating":              "평점",
+	"rate.comment_placeholder": "어떤 분께 추천하고 싶으신가요?",
+	"rate.comment":             "한 줄 평",
+	"rate.title":               "책 평가하기",
+	"rate.submit":              "남기기",
+	"rate.cancel":              "취소",
+	"rate.thanks":              "평가를 남겨주셔서 감사해요! 다른 분들이 책을 고르는 데 큰 도움이 될 거예요 :)",
+
+	// 구매 신청
+	"request.unknown_author": "저자 미상",
+	"request.info":           ">*%d. %s*\n>%s, @%s 님 신청 (%s)\n>추천 %d명, 현재 상태: *%s*",
+	"request.done":           "구매 신청이 완료되었어요! 다른 분들의 추천을 많이 받을수록 먼저 구매돼요.\n책이 도서관에 들어오면 알려드릴게요 :)",
+	"request.list_header":    "진행 중인 구매 신청이 *%d개* 있어요.",
+	"request.list_empty":     "읽고 싶은 책이 있다면 `/도서관 신청 <책 제목>` 으로 신청해보세요!",
+	"request.list_too_many":  "추천을 많이 받은 10개까지만 표시돼요.",
+	"request.voted":          "*%s* 을(를) 추천했어요! 지금까지 %d명이 이 책을 원하고 있어요.",
+	"request.status_changed": "%d번 신청(%s)을 '%s' 상태로 변경했어요.",
+	"notify.request_stocked": "신청하신 *%s* 이(가) 도서관에 들어왔어요! (위치: %s)\n`/도서관 검색 %s` 로 대출해보세요.",
+
+	// 추천
+	"recommendation.header":             "@%s 님께 추천드리는 책이에요.",
+	"recommendation.empty":              "@%s 님께 추천드릴 책을 아직 찾지 못했어요. 책을 더 대출해주시면 추천해드릴게요!",
+	"recommendation.reason.co_borrowed": "읽으신 책을 대출한 분들이 함께 읽은 책이에요.",
+	"recommendation.reason.author":      "자주 읽으신 %s 님의 책이에요.",
+	"recommendation.reason.category":    "자주 읽으신 분야(%s)의 책이에요.",
+	"recommendation.reason.popular":     "%s명이 읽은 인기 도서예요.",
+
+	// 재고 조사, 분실
+	"stocktake.opened":            "%d번 재고 조사를 시작했어요! 서가의 책을 `/도서관 확인 <책 번호> [발견 위치]` 로 확인하거나, 책의 QR 코드를 스캔해주세요.",
+	"stocktake.seen":              "#%d *%s* 을(를) 확인했어요.",
+	"stocktake.report_interim":    "%d번 재고 조사 중간 결과예요. (%s 시작)",
+	"stocktake.report_closed":     "%d번 재고 조사가 종료되었어요. (%s ~ %s)",
+	"stocktake.report_progress":   "전체 %d권 중 *%d권* 을 확인했어요.",
+	"stocktake.missing":           "*사내 비치 상태지만 확인되지 않은 책 (%d권)*",
+	"stocktake.borrowed_on_shelf": "*대출 상태지만 서가에서 발견된 책 (%d권)*",
+	"stocktake.misplaced":         "*위치가 다른 책 (%d권)*",
+	"stocktake.misplaced_book":    ">#%d *%s* (%s → 발견 위치 %s)",
+	"lost.header":                 "분실된 책이 *%d권* 있어요.",
+	"lost.borrower":               "*@%s* 님 (%d권)",
+	"lost.shelf":                  "*서가에서 분실* (%d권)",
+
+	// 언어
+	"language.changed": "앞으로 한국어로 안내해드릴게요.",
+
+	// API 토큰
+	"token.issued": "API 토큰을 발급했어요. 다른 사람에게 노출되지 않도록 주의해주세요!\n```%s```\n%s까지 사용할 수 있어요. 요청의 `Authorization: Bearer <토큰>` 헤더에 넣어 사용하세요.",
+
+	// 라벨, 도서 목록 PDF
+	"labels.title":             "도서 라벨",
+	"catalog.title":            "도서 목록",
+	"catalog.summary":          "총 %d권, %s 기준",
+	"catalog.column_title":     "제목",
+	"catalog.column_author":    "저자",
+	"catalog.column_publisher": "출판사",
+	"catalog.column_status":    "상태",
+	"catalog.no_position":      "(위치 미지정)",
+	"catalog.position":         "%s (%d권)",
+
+	// 공개 도서 목록 페이지
+	"catalog.search_placeholder":  "제목으로 검색",
+	"catalog.search":              "검색",
+	"catalog.filter_all":          "모든 책",
+	"catalog.filter_available":    "대출 가능",
+	"catalog.filter_borrowed":     "대출 중",
+	"catalog.filter_any_position": "모든 위치",
+	"catalog.count":               "%d권",
+	"catalog.empty":               "조건에 맞는 책이 없어요.",
+	"catalog.available":           "대출 가능",
+	"catalog.borrowed":            "대출 중",
+	"catalog.borrowed_until":      "대출 중 (%s 반납 예정)",
+	"catalog.rating":              "★ %.1f (%d명)",
+	"catalog.book_position":       "위치: %s",
+	"catalog.reviews":             "평점과 리뷰",
+	"catalog.no_reviews":          "아직 리뷰가 없어요.",
+	"catalog.back":                "목록으로",
+
+	// 웹 페이지
+	"web.book_author":        "%s 지음, %s",
+	"web.book_status":        "현재 상태:",
+	"web.book_due":           "%s 반납 예정",
+	"web.signed_in_as":       "@%s 님으로 로그인했어요.",
+	"web.logout":             "로그아웃",
+	"web.login_title":        "도서관 로그인",
+	"web.login_help":         "Slack에서 `/도서관 토큰` 으로 발급받은 토큰을 입력해주세요. 이 기기에서는 브라우저를 닫을 때까지 로그인이 유지돼요.",
+	"web.login_required":     "로그인한 뒤에 이용할 수 있어요.",
+	"web.login_to_borrow":    "로그인하고 대출/반납하기",
+	"web.login_to_stocktake": "로그인하고 재고 조사하기",
+	"web.borrowed":           "대출이 완료되었어요! %s 까지 반납해주세요.",
+	"web.returned":           "반납이 완료되었어요. 이용해주셔서 감사해요!",
+	"web.seen":               "#%d %s 을(를) 확인했어요.",
+	"web.stocktake_seen":     "재고 조사: 서가에서 확인했어요",
+	"web.stocktake_title":    "재고 조사",
+	"web.stocktake_progress": "%d번 재고 조사 진행 중 (%s 시작, %d / %d권 확인)",
+	"web.book_id":            "책 번호",
+	"web.seen_position":      "발견 위치 (카탈로그 위치와 같다면 비워두세요)",
+	"web.confirm":            "확인",
+	"web.stocktake_none":     "진행 중인 재고 조사가 없어요. 관리자가 Slack에서 `/도서관 재고조사 시작` 으로 시작할 수 있어요.",
+
+	// 관리 콘솔
+	"admin.title":            "도서관 관리",
+	"admin.nav_catalog":      "도서 목록",
+	"admin.nav_loans":        "대출 현황",
+	"admin.nav_overdue":      "연체 목록",
+	"admin.nav_audit":        "변경 기록",
+	"admin.nav_new_book":     "책 추가",
+	"admin.signed_in":        "@%s 님 (%s)",
+	"admin.logout":           "로그아웃",
+	"admin.login_title":      "관리 콘솔 로그인",
+	"admin.login_help":       "Slack에서 `/도서관 토큰` 으로 발급받은 토큰을 입력해주세요. 사서와 관리자만 사용할 수 있어요.",
+	"admin.token":            "API 토큰",
+	"admin.login":            "로그인",
+	"admin.librarian_only":   "사서와 관리자만 관리 콘솔을 사용할 수 있어요.",
+	"admin.search":           "검색",
+	"admin.include_archived": "분실/파손/폐기된 책 포함",
+	"admin.books_count":      "%d권",
+	"admin.column_id":        "번호",
+	"admin.column_title":     "제목",
+	"admin.column_author":    "저자",
+	"admin.column_publisher": "출판사",
+	"admin.column_position":  "위치",
+	"admin.column_status":    "상태",
+	"admin.column_borrower":  "대출자",
+	"admin.column_due_date":  "반납 예정일",
+	"admin.column_at":        "일시",
+	"admin.column_actor":     "처리자",
+	"admin.column_action":    "작업",
+	"admin.column_detail":    "내용",
+	"admin.empty":            "항목이 없어요.",
+	"admin.save":             "저장",
+	"admin.saved":            "저장했어요.",
+	"admin.loan":             "대출",
+	"admin.borrowed_by":      "@%s 님이 대출 중이에요. (%s 반납 예정)",
+	"admin.not_borrowed":     "대출 중이 아니에요.",
+	"admin.overdue":          "연체",
+	"admin.change_due_date":  "반납 기한 변경",
+	"admin.reassign":         "대출자 변경",
+	"admin.force_return":     "강제 반납",
+	"admin.change_status":    "상태 변경",
+	"admin.archive":          "보관 (폐기)",
+	"admin.archive_confirm":  "이 책을 폐기 상태로 보관할까요?",
+	"audit.create":           "책 추가",
+	"audit.edit":             "정보 수정",
+	"audit.archive":          "보관",
+	"audit.delete":           "삭제",
+	"audit.status":           "상태 변경",
+	"audit.force_return":     "강제 반납",
+	"audit.due_date":         "반납 기한 변경",
+	"audit.reassign":         "대출자 변경",
+}
@@ -0,0 +1,70 @@
+// Package logging writes structured JSON logs, tagged with the ID of the request they belong to.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type requestIDKey struct{}
+
+// Setup makes the standard logger write JSON at the level, such as debug, info, warn or error.
+// Logs of libraries using the log package are written as info.
+func Setup(level string) {
+	logrus.SetFormatter(&logrus.JSONFormatter{})
+
+	parsed, err := logrus.ParseLevel(level)
+	if err != nil {
+		logrus.WithField("level", level).Warn("Unknown log level, using info")
+		parsed = logrus.InfoLevel
+	}
+	logrus.SetLevel(parsed)
+
+	log.SetFlags(0)
+	log.SetOutput(logrus.StandardLogger().WriterLevel(logrus.InfoLevel))
+}
+
+// NewRequestID returns a random ID for a request that came without one
+func NewRequestID() string {
+	id := make([]byte, 8)
+	rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+// WithRequestID returns a copy of the context carrying the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the ID of the request the context belongs to, or an empty string outside a request
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// FromContext returns the logger for the context, which adds the request ID to every log,
+// and the trace and span IDs when the context is traced
+func FromContext(ctx context.Context) *logrus.Entry {
+	entry := logrus.NewEntry(logrus.StandardLogger())
+	if id := RequestID(ctx); id != "" {
+		entry = entry.WithField("request_id", id)
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		entry = entry.WithFields(logrus.Fields{"trace_id": span.TraceID().String(), "span_id": span.SpanID().String()})
+	}
+
+	return entry
+}
+
+// Detach returns a context for work that goes on after the request, such as sending a webhook.
+// It keeps the request ID and the trace, but is not canceled when the request ends.
+func Detach(ctx context.Context) context.Context {
+	detached := trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(ctx))
+	return WithRequestID(detached, RequestID(ctx))
+}
@@ -1,26 +1,161 @@
 package main
 
 import (
+	"context"
+	"net"
+	"time"
+
 	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
 
 	handlers "github.com/harrydrippin/go-spreadsheet-library/handler"
+	"github.com/harrydrippin/go-spreadsheet-library/logging"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
 	repositories "github.com/harrydrippin/go-spreadsheet-library/repository"
 	services "github.com/harrydrippin/go-spreadsheet-library/service"
+	"github.com/harrydrippin/go-spreadsheet-library/tracing"
 	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
 )
 
 func main() {
-	e := echo.New()
 	config := utils.NewConfig()
+	logging.Setup(config.LogLevel)
 
-	repository := repositories.NewSpreadsheetRepository(*config)
-	service := services.NewLibraryService(repository)
+	shutdownTracing, err := tracing.Setup(context.Background(), config.TracingEndpoint)
+	if err != nil {
+		logrus.WithError(err).Fatal("Unable to set up tracing")
+	}
+	// 서버는 Fatal로만 종료되므로, 종료하기 전에 남은 스팬을 내보냄
+	logrus.RegisterExitHandler(func() {
+		shutdownTracing(context.Background())
+	})
+
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+	e.HTTPErrorHandler = handlers.HandleError
+	e.Use(handlers.RequestLogger)
+	e.Use(handlers.Trace)
 
+	googleTokens := utils.GetGoogleTokenSource(config.GoogleCredentialJSON)
+	sheetService := repositories.NewSheetService(googleTokens)
+	repository := repositories.NewSpreadsheetRepository(*config, sheetService)
+	purchaseRequestRepository := repositories.NewSpreadsheetPurchaseRequestRepository(*config, sheetService)
+	reviewRepository := repositories.NewSpreadsheetReviewRepository(*config, sheetService)
+	loanRepository := repositories.NewSpreadsheetLoanRepository(*config, sheetService)
+	stocktakeRepository := repositories.NewSpreadsheetStocktakeRepository(*config, sheetService)
+	preferenceRepository := repositories.NewSpreadsheetPreferenceRepository(*config, sheetService)
+	auditRepository := repositories.NewSpreadsheetAuditRepository(*config, sheetService)
+	roleRepository := repositories.NewConfigRoleRepository(*config)
+	webhookRepository := repositories.NewConfigWebhookRepository(*config)
+	webhookDeliveryRepository := repositories.NewSpreadsheetWebhookDeliveryRepository(*config, sheetService)
+	healthRepository := repositories.NewSpreadsheetHealthRepository(*config, sheetService, googleTokens)
+
+	preferenceService := services.NewPreferenceService(preferenceRepository)
+	roles := handlers.NewSlackRoleResolver(services.NewRoleService(roleRepository), *config)
+	locales := handlers.NewSlackLocaleResolver(preferenceService, *config)
+	notifier := handlers.NewSlackNotifier(locales, *config)
+	webhookService := services.NewWebhookService(webhookRepository, webhookDeliveryRepository, roles)
+	events := services.NewEventBus()
+	events.SubscribeAsync(webhookService.Handle, models.EventBorrowed, models.EventReturned, models.EventExtended, models.EventOverdue)
+	service := services.NewTracedLibraryService(services.NewLibraryService(repository, loanRepository, auditRepository, roles, events))
+	purchaseService := services.NewPurchaseRequestService(purchaseRequestRepository, repository, notifier, roles)
+	reviewService := services.NewReviewService(reviewRepository, repository)
+	recommendationService := services.NewRecommendationService(repository, loanRepository)
+	stocktakeService := services.NewStocktakeService(stocktakeRepository, repository, roles)
+	authService := services.NewAuthService(config.APIJWTSecret, config.APITokenTTL, roles)
+	healthService := services.NewHealthService(config.HealthCheckTTL)
+	healthService.Register("google_token", healthRepository.CheckToken)
+	healthService.Register("sheets", healthRepository.Ping)
+	healthService.Register("slack", handlers.NewSlackAuthCheck(*config))
+	healthService.Register("preferences_cache", preferenceService.Warm)
+
+	metricsHandler := handlers.NewMetricsHandler(service)
+	metricsHandler.RegisterRoutes(e)
+	healthHandler := handlers.NewHealthHandler(healthService)
+	healthHandler.RegisterRoutes(e)
+	authHandler := handlers.NewAuthHandler(authService)
+	authHandler.RegisterRoutes(e)
+	openAPIHandler := handlers.NewOpenAPIHandler(*config)
+	openAPIHandler.RegisterRoutes(e)
 	restfulHandler := handlers.NewRESTfulHandler(service)
 	restfulHandler.RegisterRoutes(e)
-	slackHandler := handlers.NewSlackHandler(service, *config)
+	restfulV2Handler := handlers.NewRESTfulV2Handler(service)
+	restfulV2Handler.RegisterRoutes(e)
+	graphQLHandler := handlers.NewGraphQLHandler(service, *config)
+	graphQLHandler.RegisterRoutes(e)
+	slackHandler := handlers.NewSlackHandler(service, purchaseService, reviewService, recommendationService, stocktakeService, preferenceService, authService, roles, locales, *config)
 	slackHandler.RegisterRoutes(e)
+	webHandler := handlers.NewWebHandler(service, stocktakeService, authService, *config)
+	webHandler.RegisterRoutes(e)
+	catalogHandler := handlers.NewCatalogHandler(service, reviewService)
+	catalogHandler.RegisterRoutes(e)
+	adminHandler := handlers.NewAdminHandler(service, authService)
+	adminHandler.RegisterRoutes(e)
+	purchaseRequestHandler := handlers.NewPurchaseRequestHandler(purchaseService)
+	purchaseRequestHandler.RegisterRoutes(e)
+	reviewHandler := handlers.NewReviewHandler(reviewService)
+	reviewHandler.RegisterRoutes(e)
+	recommendationHandler := handlers.NewRecommendationHandler(recommendationService)
+	recommendationHandler.RegisterRoutes(e)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	webhookHandler.RegisterRoutes(e)
+
+	// 구매 완료된 책이 도서 목록에 추가되었는지 주기적으로 확인하여 신청자에게 알림
+	go func() {
+		for range time.Tick(10 * time.Minute) {
+			ctx := backgroundContext()
+			if err := purchaseService.NotifyStocked(ctx); err != nil {
+				logging.FromContext(ctx).WithError(err).Error("Unable to notify the stocked purchase requests")
+			}
+		}
+	}()
+
+	// 반납 기한이 지난 책을 주기적으로 연체 상태로 바꾸고 웹훅으로 알림
+	go func() {
+		for range time.Tick(time.Hour) {
+			ctx := backgroundContext()
+			if _, err := service.MarkOverdue(ctx); err != nil {
+				logging.FromContext(ctx).WithError(err).Error("Unable to mark the overdue books")
+			}
+		}
+	}()
+
+	// 대출 현황 지표는 시트를 모두 읽어야 하므로 요청마다 세지 않고 주기적으로 갱신함
+	go func() {
+		for {
+			ctx := backgroundContext()
+			if err := metricsHandler.Refresh(ctx); err != nil {
+				logging.FromContext(ctx).WithError(err).Error("Unable to refresh the book metrics")
+			}
+			time.Sleep(time.Minute)
+		}
+	}()
+
+	// 첫 준비 상태 확인과 첫 명령어가 시트를 기다리지 않도록 캐시를 미리 채움
+	go func() {
+		ctx := backgroundContext()
+		if err := preferenceService.Warm(ctx); err != nil {
+			logging.FromContext(ctx).WithError(err).Warn("Unable to warm the preference cache")
+		}
+	}()
+
+	// 다른 백엔드를 위한 gRPC 서버를 함께 실행
+	grpcServer := handlers.NewGRPCServer(service, authService)
+	go func() {
+		listener, err := net.Listen("tcp", config.GRPCAddress)
+		if err != nil {
+			logrus.WithError(err).Fatal("Unable to listen for gRPC")
+		}
+		logrus.WithField("address", config.GRPCAddress).Info("Starting gRPC server")
+		logrus.WithError(grpcServer.Serve(listener)).Fatal("gRPC server stopped")
+	}()
+
+	logrus.WithField("address", ":8080").Info("Starting server")
+	logrus.WithError(e.Start(":8080")).Fatal("Server stopped")
+}
 
-	e.Logger.Debug("Starting server on port 8080")
-	e.Logger.Fatal(e.Start(":8080"))
+// backgroundContext returns the context for a run of a periodic job, with its own ID to tell its logs apart
+func backgroundContext() context.Context {
+	return logging.WithRequestID(context.Background(), logging.NewRequestID())
 }
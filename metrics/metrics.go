@@ -0,0 +1,69 @@
+// Package metrics defines the Prometheus metrics of the library, served on /metrics.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Label values for the requests that match no route, command or action, so that their labels stay bounded
+const (
+	Unmatched = "unmatched"
+	Unknown   = "unknown"
+)
+
+var (
+	// HTTPRequests counts the HTTP requests by method, echo route and status code
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "library_http_requests_total",
+		Help: "HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPDuration observes the time taken to serve the HTTP requests by method and echo route
+	HTTPDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "library_http_request_duration_seconds",
+		Help:    "Time taken to serve HTTP requests by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// SlackCommands counts the slash commands by subcommand
+	SlackCommands = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "library_slack_commands_total",
+		Help: "Slack slash commands by subcommand.",
+	}, []string{"command"})
+
+	// SlackActions counts the button actions by ActionID and the modal submissions by CallbackID
+	SlackActions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "library_slack_actions_total",
+		Help: "Slack block actions and view submissions by action or callback ID.",
+	}, []string{"action"})
+
+	// SheetsDuration observes the time taken by the Sheets API calls by operation and sheet
+	SheetsDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "library_sheets_request_duration_seconds",
+		Help:    "Time taken by Google Sheets API calls by operation and sheet.",
+		Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"operation", "sheet"})
+
+	// SheetsErrors counts the failed Sheets API calls by operation and sheet
+	SheetsErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "library_sheets_errors_total",
+		Help: "Failed Google Sheets API calls by operation and sheet.",
+	}, []string{"operation", "sheet"})
+
+	// Books is the number of books that are available, borrowed or overdue, as of the last refresh
+	Books = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "library_books",
+		Help: "Books in circulation by availability: available, borrowed or overdue.",
+	}, []string{"state"})
+)
+
+// ObserveSheets records a Sheets API call that started at the time, and whether it failed
+func ObserveSheets(operation string, sheet string, start time.Time, err error) {
+	SheetsDuration.WithLabelValues(operation, sheet).Observe(time.Since(start).Seconds())
+	if err != nil {
+		SheetsErrors.WithLabelValues(operation, sheet).Inc()
+	}
+}
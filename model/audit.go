@@ -0,0 +1,22 @@
+package model
+
+// Actions recorded in the audit trail
+const (
+	AuditCreate      = "create"
+	AuditEdit        = "edit"
+	AuditArchive     = "archive"
+	AuditDelete      = "delete"
+	AuditStatus      = "status"
+	AuditForceReturn = "force_return"
+	AuditDueDate     = "due_date"
+	AuditReassign    = "reassign"
+)
+
+// AuditEntry is a record of a change to the catalog made by a librarian or an admin.
+type AuditEntry struct {
+	At     string `json:"at"`
+	Actor  string `json:"actor"`
+	Action string `json:"action"`
+	BookID int    `json:"book_id"`
+	Detail string `json:"detail"`
+}
@@ -1,12 +1,5 @@
 package model
 
-// Constants for representing book status
-const (
-	StatusInOffice = "사내 비치"
-	StatusBorrowed = "대출"
-	StatusOverdue  = "연체"
-)
-
 // Book is a simple data structure to represent a book.
 type Book struct {
 	ID        int    `json:"id"`
@@ -14,13 +7,21 @@ type Book struct {
 	Author    string `json:"author"`
 	Publisher string `json:"publisher"`
 	Position  string `json:"position"`
-	Status    string `json:"status"`
+	Status    Status `json:"status"`
 	Borrower  string `json:"borrower"`
 	DueDate   string `json:"due_date"`
 }
 
+// BookDetails are the details of a book a librarian can edit. Empty fields are left as they are.
+type BookDetails struct {
+	Title     string `json:"title"`
+	Author    string `json:"author"`
+	Publisher string `json:"publisher"`
+	Position  string `json:"position"`
+}
+
 // NewBook creates a new book with the given parameters.
-func NewBook(id int, title, author, publisher, position, status, borrower, dueDate string) Book {
+func NewBook(id int, title, author, publisher, position string, status Status, borrower, dueDate string) Book {
 	return Book{
 		ID:        id,
 		Title:     title,
@@ -32,3 +33,22 @@ func NewBook(id int, title, author, publisher, position, status, borrower, dueDa
 		DueDate:   dueDate,
 	}
 }
+
+// IsInCirculation reports whether the book can be borrowed now or after it is returned.
+func (b Book) IsInCirculation() bool {
+	return b.Status == StatusInOffice || b.Status == StatusBorrowed || b.Status == StatusOverdue
+}
+
+// IsAvailable reports whether the book is on the shelf and can be borrowed right now.
+func (b Book) IsAvailable() bool {
+	return b.Status == StatusInOffice
+}
+
+// IsOverdue reports whether the book is borrowed past its due date, given today as YYYY-MM-DD.
+func (b Book) IsOverdue(today string) bool {
+	if b.Status == StatusOverdue {
+		return true
+	}
+
+	return b.Status == StatusBorrowed && b.DueDate != "" && b.DueDate < today
+}
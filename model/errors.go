@@ -0,0 +1,26 @@
+package model
+
+import "strings"
+
+// DomainError is a kind of failure in the library, identified by a stable, machine-readable code.
+// Errors returned by the services and repositories wrap one of the errors below.
+type DomainError struct {
+	Code string
+}
+
+func (e *DomainError) Error() string {
+	return strings.ReplaceAll(e.Code, "_", " ")
+}
+
+// Kinds of failure in the library
+var (
+	ErrAlreadyBorrowed = &DomainError{Code: "already_borrowed"}
+	ErrNotBorrowed     = &DomainError{Code: "not_borrowed"}
+	ErrNotBorrower     = &DomainError{Code: "not_borrower"}
+	ErrNotFound        = &DomainError{Code: "not_found"}
+	ErrUnauthenticated = &DomainError{Code: "unauthenticated"}
+	ErrConflict        = &DomainError{Code: "conflict"}
+	ErrForbidden       = &DomainError{Code: "forbidden"}
+	ErrInvalidInput    = &DomainError{Code: "invalid_input"}
+	ErrUnavailable     = &DomainError{Code: "backend_unavailable"}
+)
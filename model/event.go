@@ -0,0 +1,58 @@
+package model
+
+// Names of the domain events
+const (
+	EventBorrowed      = "book.borrowed"
+	EventReturned      = "book.returned"
+	EventExtended      = "book.extended"
+	EventOverdue       = "book.overdue"
+	EventStatusChanged = "book.status_changed"
+)
+
+// DomainEvent is something that happened to a book in the library, published after the change is saved.
+type DomainEvent interface {
+	EventName() string
+	EventBook() Book
+}
+
+// BookBorrowed is published when a user borrows a book.
+type BookBorrowed struct {
+	Book Book
+}
+
+// BookReturned is published when a book is returned, by its borrower or by a librarian for them.
+// Book no longer has the borrower, who is kept in Borrower.
+type BookReturned struct {
+	Book     Book
+	Borrower string
+}
+
+// LoanExtended is published when a borrower extends the due date of a book.
+type LoanExtended struct {
+	Book            Book
+	PreviousDueDate string
+}
+
+// BookOverdue is published when a borrowed book is found past its due date.
+type BookOverdue struct {
+	Book Book
+}
+
+// BookStatusChanged is published when a librarian or an admin changes the status of a book, such as lost or withdrawn.
+type BookStatusChanged struct {
+	Book     Book
+	Previous Status
+	Actor    string
+}
+
+func (e BookBorrowed) EventName() string      { return EventBorrowed }
+func (e BookReturned) EventName() string      { return EventReturned }
+func (e LoanExtended) EventName() string      { return EventExtended }
+func (e BookOverdue) EventName() string       { return EventOverdue }
+func (e BookStatusChanged) EventName() string { return EventStatusChanged }
+
+func (e BookBorrowed) EventBook() Book      { return e.Book }
+func (e BookReturned) EventBook() Book      { return e.Book }
+func (e LoanExtended) EventBook() Book      { return e.Book }
+func (e BookOverdue) EventBook() Book       { return e.Book }
+func (e BookStatusChanged) EventBook() Book { return e.Book }
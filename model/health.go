@@ -0,0 +1,33 @@
+package model
+
+// Statuses of the server and of each dependency in a readiness report
+const (
+	HealthOK          = "ok"
+	HealthUnavailable = "unavailable"
+)
+
+// Reasons a dependency check failed. The readiness report is public, so it only tells the kind of failure
+// and the error itself is logged.
+const (
+	HealthErrorTimeout = "timeout"
+	HealthErrorFailed  = "check_failed"
+)
+
+// DependencyHealth is the result of the last check of a dependency, which may have been cached
+type DependencyHealth struct {
+	Status    string  `json:"status"`
+	Error     string  `json:"error,omitempty"`
+	CheckedAt string  `json:"checked_at"`
+	Duration  float64 `json:"duration"`
+}
+
+// Readiness tells whether the server can serve requests, with the health of each dependency by name
+type Readiness struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]DependencyHealth `json:"dependencies"`
+}
+
+// Ready returns whether every dependency is healthy
+func (r Readiness) Ready() bool {
+	return r.Status == HealthOK
+}
@@ -0,0 +1,26 @@
+package model
+
+// Scopes an API caller can be granted
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+	ScopeAdmin = "admin" // librarians and admins only
+)
+
+// Identity is the authenticated caller of the API: a Slack user name with the role of the user and the scopes granted to the caller.
+type Identity struct {
+	User   string   `json:"user"`
+	Role   Role     `json:"role"`
+	Scopes []string `json:"scopes"`
+}
+
+// HasScope reports whether the caller was granted the scope.
+func (i Identity) HasScope(scope string) bool {
+	for _, granted := range i.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+
+	return false
+}
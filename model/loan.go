@@ -0,0 +1,14 @@
+package model
+
+// Loan is a record of a book borrowed by a user, kept after the book is returned.
+type Loan struct {
+	BookID     int    `json:"book_id"`
+	Borrower   string `json:"borrower"`
+	BorrowedAt string `json:"borrowed_at"`
+	ReturnedAt string `json:"returned_at"`
+}
+
+// IsOpen reports whether the book has not been returned yet.
+func (l Loan) IsOpen() bool {
+	return l.ReturnedAt == ""
+}
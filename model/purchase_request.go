@@ -0,0 +1,70 @@
+package model
+
+// RequestStatus is the status of a purchase request, stored in the spreadsheet as its Korean label.
+type RequestStatus string
+
+// Constants for representing purchase request status
+const (
+	RequestStatusPending   RequestStatus = "신청"
+	RequestStatusApproved  RequestStatus = "승인"
+	RequestStatusRejected  RequestStatus = "반려"
+	RequestStatusPurchased RequestStatus = "구매 완료"
+	RequestStatusStocked   RequestStatus = "입고 완료"
+)
+
+// requestStatusLabels holds the display label of each purchase request status per locale.
+var requestStatusLabels = map[string]map[RequestStatus]string{
+	"en": {
+		RequestStatusPending:   "Requested",
+		RequestStatusApproved:  "Approved",
+		RequestStatusRejected:  "Rejected",
+		RequestStatusPurchased: "Purchased",
+		RequestStatusStocked:   "Stocked",
+	},
+}
+
+// Label returns the display label of the status in the locale, falling back to Korean.
+func (s RequestStatus) Label(locale string) string {
+	if label, ok := requestStatusLabels[locale][s]; ok {
+		return label
+	}
+
+	return string(s)
+}
+
+// PurchaseRequest is a request to buy a book that the library doesn't have.
+type PurchaseRequest struct {
+	ID        int           `json:"id"`
+	Title     string        `json:"title"`
+	Author    string        `json:"author"`
+	Requester string        `json:"requester"`
+	Voters    []string      `json:"voters"`
+	Status    RequestStatus `json:"status"`
+	CreatedAt string        `json:"created_at"`
+	Memo      string        `json:"memo"`
+}
+
+// Votes returns the number of users who want this book, including the requester.
+func (r PurchaseRequest) Votes() int {
+	return len(r.Voters) + 1
+}
+
+// HasVoted reports whether the user has already requested or voted for this book.
+func (r PurchaseRequest) HasVoted(user string) bool {
+	if r.Requester == user {
+		return true
+	}
+
+	for _, voter := range r.Voters {
+		if voter == user {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsOpen reports whether the request is still waiting for the book to arrive.
+func (r PurchaseRequest) IsOpen() bool {
+	return r.Status == RequestStatusPending || r.Status == RequestStatusApproved || r.Status == RequestStatusPurchased
+}
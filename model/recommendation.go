@@ -0,0 +1,18 @@
+package model
+
+// Reasons for recommending a book
+const (
+	ReasonCoBorrowed = "co_borrowed"
+	ReasonAuthor     = "author"
+	ReasonCategory   = "category"
+	ReasonPopular    = "popular"
+)
+
+// Recommendation is a book recommended to a user with the reason for it.
+// ReasonDetail is the author, the position or the number of readers depending on the reason.
+type Recommendation struct {
+	Book         Book    `json:"book"`
+	Score        float64 `json:"score"`
+	Reason       string  `json:"reason"`
+	ReasonDetail string  `json:"reason_detail"`
+}
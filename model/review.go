@@ -0,0 +1,18 @@
+package model
+
+// Review is a rating with an optional short comment left by a borrower.
+type Review struct {
+	BookID    int    `json:"book_id"`
+	Reviewer  string `json:"reviewer"`
+	Rating    int    `json:"rating"`
+	Comment   string `json:"comment"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ReviewSummary is the aggregated ratings of a book with its most recent reviews.
+type ReviewSummary struct {
+	BookID  int      `json:"book_id"`
+	Average float64  `json:"average"`
+	Count   int      `json:"count"`
+	Recent  []Review `json:"recent"`
+}
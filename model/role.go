@@ -0,0 +1,74 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Role is what a library user is allowed to do. Each role can do everything the roles below it can.
+type Role string
+
+// Constants for representing user roles, from the least privileged
+const (
+	RoleMember    Role = "member"
+	RoleLibrarian Role = "librarian"
+	RoleAdmin     Role = "admin"
+)
+
+// Roles lists every role from the least privileged.
+var Roles = []Role{RoleMember, RoleLibrarian, RoleAdmin}
+
+// roleLabels holds the display label of each role per locale.
+var roleLabels = map[string]map[Role]string{
+	"ko": {
+		RoleMember:    "회원",
+		RoleLibrarian: "사서",
+		RoleAdmin:     "관리자",
+	},
+	"en": {
+		RoleMember:    "Member",
+		RoleLibrarian: "Librarian",
+		RoleAdmin:     "Admin",
+	},
+}
+
+// ParseRole parses a role from the configuration, accepting its Korean label too.
+func ParseRole(value string) (Role, error) {
+	key := strings.ToLower(strings.TrimSpace(value))
+	for _, role := range Roles {
+		if key == string(role) || key == roleLabels["ko"][role] {
+			return role, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: unknown role %q", ErrInvalidInput, value)
+}
+
+func (r Role) rank() int {
+	for i, role := range Roles {
+		if r == role {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Includes reports whether the role can do everything the other role can.
+func (r Role) Includes(other Role) bool {
+	return r.rank() >= other.rank()
+}
+
+// Label returns the display label of the role in the locale, falling back to Korean.
+func (r Role) Label(locale string) string {
+	labels, ok := roleLabels[locale]
+	if !ok {
+		labels = roleLabels["ko"]
+	}
+
+	if label, ok := labels[r]; ok {
+		return label
+	}
+
+	return string(r)
+}
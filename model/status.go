@@ -0,0 +1,108 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Status is the circulation status of a book, stored in the spreadsheet as its Korean label.
+type Status string
+
+// Constants for representing book status
+const (
+	StatusInOffice  Status = "사내 비치"
+	StatusBorrowed  Status = "대출"
+	StatusOverdue   Status = "연체"
+	StatusLost      Status = "분실"
+	StatusDamaged   Status = "파손"
+	StatusWithdrawn Status = "폐기"
+	StatusRepairing Status = "수리중"
+)
+
+// Statuses lists every valid book status.
+var Statuses = []Status{
+	StatusInOffice, StatusBorrowed, StatusOverdue, StatusLost, StatusDamaged, StatusWithdrawn, StatusRepairing,
+}
+
+// statusAliases maps other spellings found in the sheet or typed by users to the status.
+// Keys are lowercase without spaces.
+var statusAliases = map[string]Status{
+	"비치":        StatusInOffice,
+	"사내비치":      StatusInOffice,
+	"대출가능":      StatusInOffice,
+	"available": StatusInOffice,
+	"inoffice":  StatusInOffice,
+	"대출중":       StatusBorrowed,
+	"borrowed":  StatusBorrowed,
+	"연체중":       StatusOverdue,
+	"overdue":   StatusOverdue,
+	"lost":      StatusLost,
+	"damaged":   StatusDamaged,
+	"폐기됨":       StatusWithdrawn,
+	"withdrawn": StatusWithdrawn,
+	"수리":        StatusRepairing,
+	"repairing": StatusRepairing,
+}
+
+// statusLabels holds the display label of each status per locale.
+var statusLabels = map[string]map[Status]string{
+	"ko": {
+		StatusInOffice:  "사내 비치",
+		StatusBorrowed:  "대출",
+		StatusOverdue:   "연체",
+		StatusLost:      "분실",
+		StatusDamaged:   "파손",
+		StatusWithdrawn: "폐기",
+		StatusRepairing: "수리 중",
+	},
+	"en": {
+		StatusInOffice:  "Available",
+		StatusBorrowed:  "Borrowed",
+		StatusOverdue:   "Overdue",
+		StatusLost:      "Lost",
+		StatusDamaged:   "Damaged",
+		StatusWithdrawn: "Withdrawn",
+		StatusRepairing: "Under repair",
+	},
+}
+
+// ParseStatus parses a status from a sheet value or user input, accepting aliases and ignoring spacing and case.
+func ParseStatus(value string) (Status, error) {
+	key := strings.ToLower(strings.Join(strings.Fields(value), ""))
+	for _, status := range Statuses {
+		if key == strings.Join(strings.Fields(string(status)), "") {
+			return status, nil
+		}
+	}
+
+	if status, ok := statusAliases[key]; ok {
+		return status, nil
+	}
+
+	return "", fmt.Errorf("%w: unknown book status %q", ErrInvalidInput, value)
+}
+
+// IsValid reports whether the status is one of the known statuses.
+func (s Status) IsValid() bool {
+	for _, status := range Statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Label returns the display label of the status in the locale, falling back to Korean.
+func (s Status) Label(locale string) string {
+	labels, ok := statusLabels[locale]
+	if !ok {
+		labels = statusLabels["ko"]
+	}
+
+	if label, ok := labels[s]; ok {
+		return label
+	}
+
+	return string(s)
+}
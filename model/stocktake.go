@@ -0,0 +1,39 @@
+package model
+
+// StocktakeSession is a physical audit of the shelves, open until an admin closes it.
+type StocktakeSession struct {
+	ID       int    `json:"id"`
+	OpenedBy string `json:"opened_by"`
+	OpenedAt string `json:"opened_at"`
+	ClosedAt string `json:"closed_at"`
+}
+
+// IsOpen reports whether books can still be marked as seen in the session.
+func (s StocktakeSession) IsOpen() bool {
+	return s.ClosedAt == ""
+}
+
+// Sighting is a record of a book found on the shelf during a stocktake.
+type Sighting struct {
+	SessionID int    `json:"session_id"`
+	BookID    int    `json:"book_id"`
+	Position  string `json:"position"`
+	SeenBy    string `json:"seen_by"`
+	SeenAt    string `json:"seen_at"`
+}
+
+// MisplacedBook is a book found at a different position from the catalog.
+type MisplacedBook struct {
+	Book         Book   `json:"book"`
+	SeenPosition string `json:"seen_position"`
+}
+
+// StocktakeReport is the result of a stocktake session.
+type StocktakeReport struct {
+	Session         StocktakeSession `json:"session"`
+	Total           int              `json:"total"`
+	Seen            int              `json:"seen"`
+	Missing         []Book           `json:"missing"`
+	BorrowedOnShelf []Book           `json:"borrowed_on_shelf"`
+	Misplaced       []MisplacedBook  `json:"misplaced"`
+}
@@ -0,0 +1,52 @@
+package model
+
+// EventPing is sent to a webhook by a test delivery
+const EventPing = "ping"
+
+// Webhook is an endpoint of another tool notified of loan events. A webhook without events gets every event.
+type Webhook struct {
+	Name   string   `json:"name"`
+	URL    string   `json:"url"`
+	Secret string   `json:"-"`
+	Events []string `json:"events"`
+}
+
+// Event is the payload sent to webhooks. Book is the book after the change, and is missing from a ping.
+type Event struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	At   string `json:"at"`
+	Book *Book  `json:"book,omitempty"`
+}
+
+// WebhookDelivery is a record of an attempt to deliver an event to a webhook.
+// StatusCode is 0 if the request failed before the webhook responded.
+type WebhookDelivery struct {
+	At         string `json:"at"`
+	Webhook    string `json:"webhook"`
+	EventID    string `json:"event_id"`
+	Event      string `json:"event"`
+	Attempt    int    `json:"attempt"`
+	StatusCode int    `json:"status_code"`
+	Error      string `json:"error"`
+}
+
+// Wants reports whether the webhook subscribed to the event. Pings are sent to every webhook.
+func (w Webhook) Wants(event string) bool {
+	if len(w.Events) == 0 || event == EventPing {
+		return true
+	}
+
+	for _, wanted := range w.Events {
+		if wanted == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Succeeded reports whether the webhook accepted the event.
+func (d WebhookDelivery) Succeeded() bool {
+	return d.StatusCode >= 200 && d.StatusCode < 300
+}
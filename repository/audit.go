@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+	"google.golang.org/api/sheets/v4"
+)
+
+// AuditRepository is a repository for the audit trail of the catalog
+type AuditRepository interface {
+	GetAll(ctx context.Context) ([]models.AuditEntry, error)
+	Create(ctx context.Context, entry models.AuditEntry) error
+}
+
+type SpreadsheetAuditRepository struct {
+	table sheetTable
+}
+
+func NewSpreadsheetAuditRepository(config utils.Config, sheetService *sheets.Service) *SpreadsheetAuditRepository {
+	return &SpreadsheetAuditRepository{
+		table: sheetTable{
+			sheetService:  sheetService,
+			spreadsheetID: config.GoogleSpreadsheetID,
+			sheetName:     config.GoogleAuditSheetName,
+			columns:       5,
+		},
+	}
+}
+
+func (r *SpreadsheetAuditRepository) GetAll(ctx context.Context) ([]models.AuditEntry, error) {
+	rows, err := r.table.rows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]models.AuditEntry, 0, len(rows))
+	for _, row := range rows {
+		bookId, err := strconv.Atoi(row[3])
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, models.AuditEntry{
+			At:     row[0],
+			Actor:  row[1],
+			Action: row[2],
+			BookID: bookId,
+			Detail: row[4],
+		})
+	}
+
+	return entries, nil
+}
+
+func (r *SpreadsheetAuditRepository) Create(ctx context.Context, entry models.AuditEntry) error {
+	return r.table.append(ctx, []interface{}{entry.At, entry.Actor, entry.Action, entry.BookID, entry.Detail})
+}
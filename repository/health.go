@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/sheets/v4"
+)
+
+// HealthRepository checks that the spreadsheet can be used
+type HealthRepository interface {
+	CheckToken(ctx context.Context) error
+	Ping(ctx context.Context) error
+}
+
+type SpreadsheetHealthRepository struct {
+	config       utils.Config
+	sheetService *sheets.Service
+	tokens       oauth2.TokenSource
+}
+
+func NewSpreadsheetHealthRepository(config utils.Config, sheetService *sheets.Service, tokens oauth2.TokenSource) *SpreadsheetHealthRepository {
+	return &SpreadsheetHealthRepository{config: config, sheetService: sheetService, tokens: tokens}
+}
+
+// CheckToken fails if the Google token has expired and cannot be refreshed, such as when the refresh token was revoked
+func (r *SpreadsheetHealthRepository) CheckToken(ctx context.Context) error {
+	// 토큰이 유효하면 캐시된 토큰을 돌려주므로, 만료되었을 때만 Google에 요청함
+	token, err := r.tokens.Token()
+	if err != nil {
+		return err
+	}
+	if !token.Valid() {
+		return fmt.Errorf("the Google token is not valid")
+	}
+
+	return nil
+}
+
+// Ping reads the first cell of the book sheet, which fails if the Sheets API or the spreadsheet cannot be reached
+func (r *SpreadsheetHealthRepository) Ping(ctx context.Context) error {
+	readRange := fmt.Sprintf("%s!A1", r.config.GoogleSpreadsheetName)
+	_, err := getValues(ctx, r.sheetService, r.config.GoogleSpreadsheetID, r.config.GoogleSpreadsheetName, readRange)
+
+	return err
+}
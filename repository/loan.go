@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+	"google.golang.org/api/sheets/v4"
+)
+
+// LoanRepository is a repository for the loan history
+type LoanRepository interface {
+	GetAll(ctx context.Context) ([]models.Loan, error)
+	SearchByBorrower(ctx context.Context, borrower string) ([]models.Loan, error)
+	Create(ctx context.Context, loan models.Loan) error
+	Close(ctx context.Context, bookId int, borrower string, returnedAt string) error
+}
+
+type SpreadsheetLoanRepository struct {
+	table sheetTable
+}
+
+func NewSpreadsheetLoanRepository(config utils.Config, sheetService *sheets.Service) *SpreadsheetLoanRepository {
+	return &SpreadsheetLoanRepository{
+		table: sheetTable{
+			sheetService:  sheetService,
+			spreadsheetID: config.GoogleSpreadsheetID,
+			sheetName:     config.GoogleLoanSheetName,
+			columns:       4,
+		},
+	}
+}
+
+func (r *SpreadsheetLoanRepository) GetAll(ctx context.Context) ([]models.Loan, error) {
+	rows, err := r.table.rows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loans := make([]models.Loan, 0, len(rows))
+	for _, row := range rows {
+		bookId, err := strconv.Atoi(row[0])
+		if err != nil {
+			return nil, err
+		}
+
+		loans = append(loans, models.Loan{
+			BookID:     bookId,
+			Borrower:   row[1],
+			BorrowedAt: row[2],
+			ReturnedAt: row[3],
+		})
+	}
+
+	return loans, nil
+}
+
+func (r *SpreadsheetLoanRepository) SearchByBorrower(ctx context.Context, borrower string) ([]models.Loan, error) {
+	loans, err := r.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []models.Loan{}
+	for _, loan := range loans {
+		if loan.Borrower == borrower {
+			result = append(result, loan)
+		}
+	}
+
+	return result, nil
+}
+
+func (r *SpreadsheetLoanRepository) Create(ctx context.Context, loan models.Loan) error {
+	return r.table.append(ctx, []interface{}{loan.BookID, loan.Borrower, loan.BorrowedAt, loan.ReturnedAt})
+}
+
+// Close marks the most recent open loan of the book by the borrower as returned, if there is one
+func (r *SpreadsheetLoanRepository) Close(ctx context.Context, bookId int, borrower string, returnedAt string) error {
+	loans, err := r.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for index := len(loans) - 1; index >= 0; index-- {
+		loan := loans[index]
+		if loan.BookID == bookId && loan.Borrower == borrower && loan.IsOpen() {
+			return r.table.update(ctx, index, []interface{}{loan.BookID, loan.Borrower, loan.BorrowedAt, returnedAt})
+		}
+	}
+
+	// 대출 기록을 남기기 전에 대출된 책은 닫을 기록이 없음
+	return nil
+}
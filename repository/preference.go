@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+	"google.golang.org/api/sheets/v4"
+)
+
+// PreferenceRepository is a repository for the preferences of each user
+type PreferenceRepository interface {
+	GetLocales(ctx context.Context) (map[string]string, error)
+	SetLocale(ctx context.Context, user string, locale string) error
+}
+
+type SpreadsheetPreferenceRepository struct {
+	table sheetTable
+}
+
+func NewSpreadsheetPreferenceRepository(config utils.Config, sheetService *sheets.Service) *SpreadsheetPreferenceRepository {
+	return &SpreadsheetPreferenceRepository{
+		table: sheetTable{
+			sheetService:  sheetService,
+			spreadsheetID: config.GoogleSpreadsheetID,
+			sheetName:     config.GooglePreferenceSheetName,
+			columns:       2,
+		},
+	}
+}
+
+// GetLocales returns the preferred locale of every user who has set one
+func (r *SpreadsheetPreferenceRepository) GetLocales(ctx context.Context) (map[string]string, error) {
+	rows, err := r.table.rows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	locales := make(map[string]string)
+	for _, row := range rows {
+		locales[row[0]] = row[1]
+	}
+
+	return locales, nil
+}
+
+func (r *SpreadsheetPreferenceRepository) SetLocale(ctx context.Context, user string, locale string) error {
+	rows, err := r.table.rows(ctx)
+	if err != nil {
+		return err
+	}
+
+	for index, row := range rows {
+		if row[0] == user {
+			return r.table.update(ctx, index, []interface{}{user, locale})
+		}
+	}
+
+	return r.table.append(ctx, []interface{}{user, locale})
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+	"google.golang.org/api/sheets/v4"
+)
+
+// PurchaseRequestRepository is a repository for a book purchase request
+type PurchaseRequestRepository interface {
+	GetAll(ctx context.Context) ([]models.PurchaseRequest, error)
+	SearchById(ctx context.Context, id int) (models.PurchaseRequest, error)
+	Create(ctx context.Context, request models.PurchaseRequest) (models.PurchaseRequest, error)
+	Update(ctx context.Context, request models.PurchaseRequest) error
+}
+
+type SpreadsheetPurchaseRequestRepository struct {
+	table sheetTable
+}
+
+func NewSpreadsheetPurchaseRequestRepository(config utils.Config, sheetService *sheets.Service) *SpreadsheetPurchaseRequestRepository {
+	return &SpreadsheetPurchaseRequestRepository{
+		table: sheetTable{
+			sheetService:  sheetService,
+			spreadsheetID: config.GoogleSpreadsheetID,
+			sheetName:     config.GooglePurchaseRequestSheetName,
+			columns:       8,
+		},
+	}
+}
+
+func (r *SpreadsheetPurchaseRequestRepository) GetAll(ctx context.Context) ([]models.PurchaseRequest, error) {
+	rows, err := r.table.rows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]models.PurchaseRequest, 0, len(rows))
+	for _, row := range rows {
+		requestId, err := strconv.Atoi(row[0])
+		if err != nil {
+			return nil, err
+		}
+
+		request := models.PurchaseRequest{
+			ID:        requestId,
+			Title:     row[1],
+			Author:    row[2],
+			Requester: row[3],
+			Voters:    []string{},
+			Status:    models.RequestStatus(row[5]),
+			CreatedAt: row[6],
+			Memo:      row[7],
+		}
+		if row[4] != "" {
+			request.Voters = strings.Split(row[4], ",")
+		}
+
+		requests = append(requests, request)
+	}
+
+	return requests, nil
+}
+
+func (r *SpreadsheetPurchaseRequestRepository) SearchById(ctx context.Context, id int) (models.PurchaseRequest, error) {
+	requests, err := r.GetAll(ctx)
+	if err != nil {
+		return models.PurchaseRequest{}, err
+	}
+
+	for _, request := range requests {
+		if request.ID == id {
+			return request, nil
+		}
+	}
+
+	return models.PurchaseRequest{}, fmt.Errorf("purchase request %d %w", id, models.ErrNotFound)
+}
+
+func (r *SpreadsheetPurchaseRequestRepository) Create(ctx context.Context, request models.PurchaseRequest) (models.PurchaseRequest, error) {
+	requests, err := r.GetAll(ctx)
+	if err != nil {
+		return models.PurchaseRequest{}, err
+	}
+
+	// 행 번호가 곧 ID이며, 서비스가 쓰기를 직렬화하므로 두 요청이 같은 번호를 받지 않음
+	request.ID = len(requests) + 1
+	err = r.table.append(ctx, r.toRow(request))
+
+	return request, err
+}
+
+func (r *SpreadsheetPurchaseRequestRepository) Update(ctx context.Context, request models.PurchaseRequest) error {
+	return r.table.update(ctx, request.ID-1, r.toRow(request))
+}
+
+func (r *SpreadsheetPurchaseRequestRepository) toRow(request models.PurchaseRequest) []interface{} {
+	return []interface{}{
+		request.ID,
+		request.Title,
+		request.Author,
+		request.Requester,
+		strings.Join(request.Voters, ","),
+		request.Status,
+		request.CreatedAt,
+		request.Memo,
+	}
+}
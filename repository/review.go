@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+	"google.golang.org/api/sheets/v4"
+)
+
+// ReviewRepository is a repository for a book review
+type ReviewRepository interface {
+	GetAll(ctx context.Context) ([]models.Review, error)
+	SearchByBookId(ctx context.Context, bookId int) ([]models.Review, error)
+	Save(ctx context.Context, review models.Review) error
+}
+
+type SpreadsheetReviewRepository struct {
+	table sheetTable
+}
+
+func NewSpreadsheetReviewRepository(config utils.Config, sheetService *sheets.Service) *SpreadsheetReviewRepository {
+	return &SpreadsheetReviewRepository{
+		table: sheetTable{
+			sheetService:  sheetService,
+			spreadsheetID: config.GoogleSpreadsheetID,
+			sheetName:     config.GoogleReviewSheetName,
+			columns:       5,
+		},
+	}
+}
+
+func (r *SpreadsheetReviewRepository) GetAll(ctx context.Context) ([]models.Review, error) {
+	rows, err := r.table.rows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := make([]models.Review, 0, len(rows))
+	for _, row := range rows {
+		bookId, err := strconv.Atoi(row[0])
+		if err != nil {
+			return nil, err
+		}
+
+		rating, err := strconv.Atoi(row[2])
+		if err != nil {
+			return nil, err
+		}
+
+		reviews = append(reviews, models.Review{
+			BookID:    bookId,
+			Reviewer:  row[1],
+			Rating:    rating,
+			Comment:   row[3],
+			CreatedAt: row[4],
+		})
+	}
+
+	return reviews, nil
+}
+
+func (r *SpreadsheetReviewRepository) SearchByBookId(ctx context.Context, bookId int) ([]models.Review, error) {
+	reviews, err := r.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []models.Review{}
+	for _, review := range reviews {
+		if review.BookID == bookId {
+			result = append(result, review)
+		}
+	}
+
+	return result, nil
+}
+
+// Save replaces the reviewer's previous review of the book, or adds a new one
+func (r *SpreadsheetReviewRepository) Save(ctx context.Context, review models.Review) error {
+	reviews, err := r.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	row := []interface{}{review.BookID, review.Reviewer, review.Rating, review.Comment, review.CreatedAt}
+	for index, existing := range reviews {
+		if existing.BookID == review.BookID && existing.Reviewer == review.Reviewer {
+			return r.table.update(ctx, index, row)
+		}
+	}
+
+	return r.table.append(ctx, row)
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+)
+
+// RoleRepository is a repository for the roles of library users
+type RoleRepository interface {
+	GetAll() (map[string]models.Role, error)
+}
+
+// ConfigRoleRepository reads the roles from the configuration: the admins and librarians in dotenv,
+// and the JSON file mapping each user name to a role such as {"harry": "admin", "kim": "librarian"}.
+type ConfigRoleRepository struct {
+	config utils.Config
+}
+
+func NewConfigRoleRepository(config utils.Config) *ConfigRoleRepository {
+	return &ConfigRoleRepository{config: config}
+}
+
+func (r *ConfigRoleRepository) GetAll() (map[string]models.Role, error) {
+	roles := make(map[string]models.Role)
+	if r.config.RolesFile != "" {
+		content, err := ioutil.ReadFile(r.config.RolesFile)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make(map[string]string)
+		if err := json.Unmarshal(content, &values); err != nil {
+			return nil, fmt.Errorf("%s: %v", r.config.RolesFile, err)
+		}
+
+		for user, value := range values {
+			role, err := models.ParseRole(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", r.config.RolesFile, err)
+			}
+			roles[user] = role
+		}
+	}
+
+	// 기존 LIBRARY_ADMINS 설정은 그대로 관리자로 인정함
+	for _, user := range r.config.LibraryLibrarians {
+		if _, ok := roles[user]; !ok {
+			roles[user] = models.RoleLibrarian
+		}
+	}
+	for _, user := range r.config.LibraryAdmins {
+		roles[user] = models.RoleAdmin
+	}
+
+	return roles, nil
+}
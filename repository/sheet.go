@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harrydrippin/go-spreadsheet-library/logging"
+	"github.com/harrydrippin/go-spreadsheet-library/metrics"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	"github.com/harrydrippin/go-spreadsheet-library/tracing"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/api/sheets/v4"
+)
+
+// sheetTable is a sheet used as a simple table: a header on the first row and one record per row below it.
+type sheetTable struct {
+	sheetService  *sheets.Service
+	spreadsheetID string
+	sheetName     string
+	columns       int
+}
+
+func (t sheetTable) lastColumn() string {
+	return string(rune('A' + t.columns - 1))
+}
+
+// rows returns every record of the table, padded to the number of columns.
+func (t sheetTable) rows(ctx context.Context) ([][]string, error) {
+	readRange := fmt.Sprintf("%s!A2:%s", t.sheetName, t.lastColumn())
+	response, err := getValues(ctx, t.sheetService, t.spreadsheetID, t.sheetName, readRange)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(response.Values))
+	for _, values := range response.Values {
+		row := make([]string, t.columns)
+		for i, value := range values {
+			if i < t.columns {
+				row[i] = fmt.Sprint(value)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// append adds a record after the last row of the table.
+func (t sheetTable) append(ctx context.Context, values []interface{}) error {
+	readRange := fmt.Sprintf("%s!A2:%s", t.sheetName, t.lastColumn())
+	valueRange := sheets.ValueRange{Values: [][]interface{}{values}}
+
+	call := t.sheetService.Spreadsheets.Values.Append(t.spreadsheetID, readRange, &valueRange).ValueInputOption("RAW").InsertDataOption("INSERT_ROWS")
+	ctx, done := startCall(ctx, "append", t.sheetName, readRange)
+	_, err := call.Context(ctx).Do()
+	done(err)
+	if err != nil {
+		return unavailable(err)
+	}
+
+	return nil
+}
+
+// update overwrites the record at the given index, where 0 is the first record below the header.
+func (t sheetTable) update(ctx context.Context, index int, values []interface{}) error {
+	rowId := index + 2
+	readRange := fmt.Sprintf("%s!A%d:%s%d", t.sheetName, rowId, t.lastColumn(), rowId)
+	valueRange := sheets.ValueRange{Values: [][]interface{}{values}}
+
+	return updateValues(ctx, t.sheetService, t.spreadsheetID, t.sheetName, readRange, &valueRange)
+}
+
+// getValues reads the range of the sheet. Like every call to the API, it is observed in the metrics, the log and the trace.
+func getValues(ctx context.Context, sheetService *sheets.Service, spreadsheetID string, sheetName string, readRange string) (*sheets.ValueRange, error) {
+	ctx, done := startCall(ctx, "get", sheetName, readRange)
+	response, err := sheetService.Spreadsheets.Values.Get(spreadsheetID, readRange).Context(ctx).Do()
+	done(err)
+	if err != nil {
+		return nil, unavailable(err)
+	}
+
+	return response, nil
+}
+
+// updateValues overwrites the range of the sheet with the values.
+func updateValues(ctx context.Context, sheetService *sheets.Service, spreadsheetID string, sheetName string, writeRange string, valueRange *sheets.ValueRange) error {
+	call := sheetService.Spreadsheets.Values.Update(spreadsheetID, writeRange, valueRange).ValueInputOption("RAW")
+	ctx, done := startCall(ctx, "update", sheetName, writeRange)
+	_, err := call.Context(ctx).Do()
+	done(err)
+	if err != nil {
+		return unavailable(err)
+	}
+
+	return nil
+}
+
+// startCall starts the span of a call to the Sheets API on the range.
+// The returned function is given the result of the call, and records it in the metrics, the log and the span.
+func startCall(ctx context.Context, operation string, sheetName string, cellRange string) (context.Context, func(error)) {
+	ctx, span := tracing.Start(ctx, "Sheets "+operation,
+		attribute.String("sheets.operation", operation),
+		attribute.String("sheets.sheet", sheetName),
+		attribute.String("sheets.range", cellRange),
+	)
+	start := time.Now()
+
+	return ctx, func(err error) {
+		observe(ctx, operation, sheetName, start, err)
+		tracing.End(span, err)
+	}
+}
+
+// observe records the call to the Sheets API that started at the time in the metrics, and logs it with the request ID
+func observe(ctx context.Context, operation string, sheetName string, start time.Time, err error) {
+	metrics.ObserveSheets(operation, sheetName, start, err)
+
+	entry := logging.FromContext(ctx).WithFields(logrus.Fields{
+		"operation": operation,
+		"sheet":     sheetName,
+		"duration":  time.Since(start).Seconds(),
+	})
+	if err != nil {
+		entry.WithError(err).Warn("Sheets API call failed")
+		return
+	}
+	entry.Debug("Sheets API call")
+}
+
+// unavailable marks an error from the Sheets API, so that callers can tell it from an error in the request.
+func unavailable(err error) error {
+	return fmt.Errorf("%w: %v", models.ErrUnavailable, err)
+}
@@ -3,48 +3,53 @@ package repository
 import (
 	"context"
 	"fmt"
-	"log"
-	"net/http"
 	"strconv"
 
+	"github.com/harrydrippin/go-spreadsheet-library/logging"
 	models "github.com/harrydrippin/go-spreadsheet-library/model"
 	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
 	"github.com/lithammer/fuzzysearch/fuzzy"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
 	"google.golang.org/api/option"
 	"google.golang.org/api/sheets/v4"
 )
 
 // BookRepository is a repository for a book
 type BookRepository interface {
-	SearchByTitle(title string) ([]models.Book, error)
-	SearchById(id int) (models.Book, error)
-	GetAll() ([]models.Book, error)
-	Update(book models.Book) error
+	SearchByTitle(ctx context.Context, title string) ([]models.Book, error)
+	SearchById(ctx context.Context, id int) (models.Book, error)
+	GetAll(ctx context.Context) ([]models.Book, error)
+	Create(ctx context.Context, book models.Book) (models.Book, error)
+	Update(ctx context.Context, book models.Book) error
+	Delete(ctx context.Context, id int) error
 }
 
 type SpreadsheetRepository struct {
 	config       utils.Config
-	client       *http.Client
 	sheetService *sheets.Service
 }
 
-func NewSpreadsheetRepository(config utils.Config) *SpreadsheetRepository {
+// NewSheetService creates a Google Sheets client shared by the spreadsheet repositories
+func NewSheetService(tokens oauth2.TokenSource) *sheets.Service {
 	ctx := context.Background()
-	client := utils.GetGoogleClient(config.GoogleCredentialJSON)
-	sheetService, err := sheets.NewService(ctx, option.WithHTTPClient(client))
+	sheetService, err := sheets.NewService(ctx, option.WithTokenSource(tokens))
 	if err != nil {
-		log.Fatal("Unable to retrieve Sheets client", err)
+		logrus.WithError(err).Fatal("Unable to retrieve Sheets client")
 	}
 
+	return sheetService
+}
+
+func NewSpreadsheetRepository(config utils.Config, sheetService *sheets.Service) *SpreadsheetRepository {
 	return &SpreadsheetRepository{
 		config:       config,
-		client:       client,
 		sheetService: sheetService,
 	}
 }
 
-func (s *SpreadsheetRepository) SearchByTitle(title string) ([]models.Book, error) {
-	books, err := s.GetAll()
+func (s *SpreadsheetRepository) SearchByTitle(ctx context.Context, title string) ([]models.Book, error) {
+	books, err := s.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -59,42 +64,54 @@ func (s *SpreadsheetRepository) SearchByTitle(title string) ([]models.Book, erro
 	return result, nil
 }
 
-func (s *SpreadsheetRepository) SearchById(id int) (models.Book, error) {
-	books, err := s.GetAll()
+func (s *SpreadsheetRepository) SearchById(ctx context.Context, id int) (models.Book, error) {
+	books, err := s.GetAll(ctx)
 	if err != nil {
 		return models.Book{}, err
 	}
 
-	return books[id], nil
+	for _, book := range books {
+		if book.ID == id {
+			return book, nil
+		}
+	}
+
+	return models.Book{}, fmt.Errorf("book %d %w", id, models.ErrNotFound)
 }
 
-func (r *SpreadsheetRepository) GetAll() ([]models.Book, error) {
+func (r *SpreadsheetRepository) GetAll(ctx context.Context) ([]models.Book, error) {
 	readRange := fmt.Sprintf("%s!A3:H", r.config.GoogleSpreadsheetName)
-	response, err := r.sheetService.Spreadsheets.Values.Get(r.config.GoogleSpreadsheetID, readRange).Do()
+	response, err := getValues(ctx, r.sheetService, r.config.GoogleSpreadsheetID, r.config.GoogleSpreadsheetName, readRange)
 	if err != nil {
 		return nil, err
 	}
 
 	books := make([]models.Book, 0)
 	for _, row := range response.Values {
+		// 삭제된 책의 행에는 책 번호가 다시 쓰이지 않도록 번호만 남아 있음
+		if len(row) < 6 {
+			continue
+		}
+
 		book := models.Book{}
-		bookId, err := strconv.ParseInt(row[0].(string), 0, 64)
+		bookId, err := strconv.ParseInt(cell(row, 0), 0, 64)
 		if err != nil {
-			return nil, err
+			skipRow(ctx, row, fmt.Errorf("%w: invalid book ID: %v", models.ErrInvalidInput, err))
+			continue
 		}
 		book.ID = int(bookId)
-		book.Title = row[1].(string)
-		book.Author = row[2].(string)
-		book.Publisher = row[3].(string)
-		book.Position = row[4].(string)
-		book.Status = row[5].(string)
-		if len(row) == 6 {
-			book.Borrower = ""
-			book.DueDate = ""
-		} else {
-			book.Borrower = row[6].(string)
-			book.DueDate = row[7].(string)
+		book.Title = cell(row, 1)
+		book.Author = cell(row, 2)
+		book.Publisher = cell(row, 3)
+		book.Position = cell(row, 4)
+		book.Status, err = models.ParseStatus(cell(row, 5))
+		if err != nil {
+			skipRow(ctx, row, fmt.Errorf("book %d: %w", book.ID, err))
+			continue
 		}
+		// 시트는 뒤쪽의 빈 칸을 돌려주지 않으므로, 대출자나 반납 기한이 없는 행은 짧을 수 있음
+		book.Borrower = cell(row, 6)
+		book.DueDate = cell(row, 7)
 
 		books = append(books, book)
 	}
@@ -102,11 +119,29 @@ func (r *SpreadsheetRepository) GetAll() ([]models.Book, error) {
 	return books, nil
 }
 
-func (r *SpreadsheetRepository) Update(book models.Book) error {
+// skipRow logs a row that cannot be read as a book. It is left out instead of failing the request,
+// so that a mistyped cell does not make every book unavailable until someone fixes the sheet.
+func skipRow(ctx context.Context, row []interface{}, err error) {
+	logging.FromContext(ctx).WithError(err).WithField("row", row).Warn("Skipping an invalid book row")
+}
+
+// cell returns the value of the column in the row, or an empty string if the row ends before it
+func cell(row []interface{}, column int) string {
+	if len(row) <= column {
+		return ""
+	}
+
+	return fmt.Sprint(row[column])
+}
+
+func (r *SpreadsheetRepository) Update(ctx context.Context, book models.Book) error {
+	if !book.Status.IsValid() {
+		return fmt.Errorf("book %d: %w: unknown book status %q", book.ID, models.ErrInvalidInput, book.Status)
+	}
+
 	rowId := book.ID + 2
 	readRange := fmt.Sprintf("%s!A%d:H%d", r.config.GoogleSpreadsheetName, rowId, rowId)
-	_, err := r.sheetService.Spreadsheets.Values.Get(r.config.GoogleSpreadsheetID, readRange).Do()
-	if err != nil {
+	if _, err := getValues(ctx, r.sheetService, r.config.GoogleSpreadsheetID, r.config.GoogleSpreadsheetName, readRange); err != nil {
 		return err
 	}
 
@@ -116,11 +151,47 @@ func (r *SpreadsheetRepository) Update(book models.Book) error {
 		},
 	}
 
-	call := r.sheetService.Spreadsheets.Values.Update(r.config.GoogleSpreadsheetID, readRange, &valueRange).ValueInputOption("RAW")
-	_, err = call.Do()
+	return updateValues(ctx, r.sheetService, r.config.GoogleSpreadsheetID, r.config.GoogleSpreadsheetName, readRange, &valueRange)
+}
+
+// Create adds the book on a new row and returns it with its ID, which is the next number after the last row
+func (r *SpreadsheetRepository) Create(ctx context.Context, book models.Book) (models.Book, error) {
+	if !book.Status.IsValid() {
+		return models.Book{}, fmt.Errorf("%w: unknown book status %q", models.ErrInvalidInput, book.Status)
+	}
+
+	readRange := fmt.Sprintf("%s!A3:A", r.config.GoogleSpreadsheetName)
+	response, err := getValues(ctx, r.sheetService, r.config.GoogleSpreadsheetID, r.config.GoogleSpreadsheetName, readRange)
 	if err != nil {
-		return err
+		return models.Book{}, err
+	}
+
+	// 삭제된 책의 번호도 남아 있으므로 마지막 행의 다음 번호를 사용함. 서비스가 추가를 직렬화하므로 번호가 겹치지 않음
+	book.ID = len(response.Values) + 1
+	rowId := book.ID + 2
+	writeRange := fmt.Sprintf("%s!A%d:H%d", r.config.GoogleSpreadsheetName, rowId, rowId)
+	valueRange := sheets.ValueRange{
+		Values: [][]interface{}{
+			{book.ID, book.Title, book.Author, book.Publisher, book.Position, book.Status, book.Borrower, book.DueDate},
+		},
+	}
+
+	if err := updateValues(ctx, r.sheetService, r.config.GoogleSpreadsheetID, r.config.GoogleSpreadsheetName, writeRange, &valueRange); err != nil {
+		return models.Book{}, err
+	}
+
+	return book, nil
+}
+
+// Delete clears the row of the book except its ID. The row stays, as the ID of a book is its position in the sheet.
+func (r *SpreadsheetRepository) Delete(ctx context.Context, id int) error {
+	rowId := id + 2
+	readRange := fmt.Sprintf("%s!A%d:H%d", r.config.GoogleSpreadsheetName, rowId, rowId)
+	valueRange := sheets.ValueRange{
+		Values: [][]interface{}{
+			{id, "", "", "", "", "", "", ""},
+		},
 	}
 
-	return nil
+	return updateValues(ctx, r.sheetService, r.config.GoogleSpreadsheetID, r.config.GoogleSpreadsheetName, readRange, &valueRange)
 }
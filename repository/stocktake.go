@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+	"google.golang.org/api/sheets/v4"
+)
+
+// StocktakeRepository is a repository for stocktake sessions and the books seen in them
+type StocktakeRepository interface {
+	GetSessions(ctx context.Context) ([]models.StocktakeSession, error)
+	CreateSession(ctx context.Context, session models.StocktakeSession) (models.StocktakeSession, error)
+	UpdateSession(ctx context.Context, session models.StocktakeSession) error
+	GetSightings(ctx context.Context, sessionId int) ([]models.Sighting, error)
+	CreateSighting(ctx context.Context, sighting models.Sighting) error
+}
+
+type SpreadsheetStocktakeRepository struct {
+	sessionTable  sheetTable
+	sightingTable sheetTable
+}
+
+func NewSpreadsheetStocktakeRepository(config utils.Config, sheetService *sheets.Service) *SpreadsheetStocktakeRepository {
+	return &SpreadsheetStocktakeRepository{
+		sessionTable: sheetTable{
+			sheetService:  sheetService,
+			spreadsheetID: config.GoogleSpreadsheetID,
+			sheetName:     config.GoogleStocktakeSheetName,
+			columns:       4,
+		},
+		sightingTable: sheetTable{
+			sheetService:  sheetService,
+			spreadsheetID: config.GoogleSpreadsheetID,
+			sheetName:     config.GoogleSightingSheetName,
+			columns:       5,
+		},
+	}
+}
+
+func (r *SpreadsheetStocktakeRepository) GetSessions(ctx context.Context) ([]models.StocktakeSession, error) {
+	rows, err := r.sessionTable.rows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]models.StocktakeSession, 0, len(rows))
+	for _, row := range rows {
+		sessionId, err := strconv.Atoi(row[0])
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, models.StocktakeSession{
+			ID:       sessionId,
+			OpenedBy: row[1],
+			OpenedAt: row[2],
+			ClosedAt: row[3],
+		})
+	}
+
+	return sessions, nil
+}
+
+func (r *SpreadsheetStocktakeRepository) CreateSession(ctx context.Context, session models.StocktakeSession) (models.StocktakeSession, error) {
+	sessions, err := r.GetSessions(ctx)
+	if err != nil {
+		return models.StocktakeSession{}, err
+	}
+
+	// 행 번호가 곧 ID이며, 서비스가 쓰기를 직렬화하므로 두 세션이 같은 번호를 받지 않음
+	session.ID = len(sessions) + 1
+	err = r.sessionTable.append(ctx, []interface{}{session.ID, session.OpenedBy, session.OpenedAt, session.ClosedAt})
+
+	return session, err
+}
+
+func (r *SpreadsheetStocktakeRepository) UpdateSession(ctx context.Context, session models.StocktakeSession) error {
+	return r.sessionTable.update(ctx, session.ID-1, []interface{}{session.ID, session.OpenedBy, session.OpenedAt, session.ClosedAt})
+}
+
+func (r *SpreadsheetStocktakeRepository) GetSightings(ctx context.Context, sessionId int) ([]models.Sighting, error) {
+	rows, err := r.sightingTable.rows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sightings := []models.Sighting{}
+	for _, row := range rows {
+		rowSessionId, err := strconv.Atoi(row[0])
+		if err != nil {
+			return nil, err
+		}
+		if rowSessionId != sessionId {
+			continue
+		}
+
+		bookId, err := strconv.Atoi(row[1])
+		if err != nil {
+			return nil, err
+		}
+
+		sightings = append(sightings, models.Sighting{
+			SessionID: rowSessionId,
+			BookID:    bookId,
+			Position:  row[2],
+			SeenBy:    row[3],
+			SeenAt:    row[4],
+		})
+	}
+
+	return sightings, nil
+}
+
+func (r *SpreadsheetStocktakeRepository) CreateSighting(ctx context.Context, sighting models.Sighting) error {
+	return r.sightingTable.append(ctx, []interface{}{sighting.SessionID, sighting.BookID, sighting.Position, sighting.SeenBy, sighting.SeenAt})
+}
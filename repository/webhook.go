@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	utils "github.com/harrydrippin/go-spreadsheet-library/utils"
+	"google.golang.org/api/sheets/v4"
+)
+
+// WebhookRepository is a repository for the webhooks notified of loan events
+type WebhookRepository interface {
+	GetAll() ([]models.Webhook, error)
+}
+
+// WebhookDeliveryRepository is a repository for the log of webhook deliveries
+type WebhookDeliveryRepository interface {
+	GetAll(ctx context.Context) ([]models.WebhookDelivery, error)
+	Create(ctx context.Context, delivery models.WebhookDelivery) error
+}
+
+// ConfigWebhookRepository reads the webhooks from the JSON file in the configuration, such as
+// [{"name": "onboarding", "url": "https://...", "secret": "...", "events": ["book.borrowed"]}].
+// The secrets are kept out of the spreadsheet, which many people can read.
+type ConfigWebhookRepository struct {
+	config utils.Config
+}
+
+func NewConfigWebhookRepository(config utils.Config) *ConfigWebhookRepository {
+	return &ConfigWebhookRepository{config: config}
+}
+
+func (r *ConfigWebhookRepository) GetAll() ([]models.Webhook, error) {
+	if r.config.WebhooksFile == "" {
+		return []models.Webhook{}, nil
+	}
+
+	content, err := ioutil.ReadFile(r.config.WebhooksFile)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []struct {
+		Name   string   `json:"name"`
+		URL    string   `json:"url"`
+		Secret string   `json:"secret"`
+		Events []string `json:"events"`
+	}{}
+	if err := json.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("%s: %v", r.config.WebhooksFile, err)
+	}
+
+	webhooks := make([]models.Webhook, 0, len(values))
+	for _, value := range values {
+		if value.Name == "" || value.URL == "" {
+			return nil, fmt.Errorf("%s: every webhook needs a name and a url", r.config.WebhooksFile)
+		}
+		webhooks = append(webhooks, models.Webhook{Name: value.Name, URL: value.URL, Secret: value.Secret, Events: value.Events})
+	}
+
+	return webhooks, nil
+}
+
+type SpreadsheetWebhookDeliveryRepository struct {
+	table sheetTable
+}
+
+func NewSpreadsheetWebhookDeliveryRepository(config utils.Config, sheetService *sheets.Service) *SpreadsheetWebhookDeliveryRepository {
+	return &SpreadsheetWebhookDeliveryRepository{
+		table: sheetTable{
+			sheetService:  sheetService,
+			spreadsheetID: config.GoogleSpreadsheetID,
+			sheetName:     config.GoogleWebhookSheetName,
+			columns:       7,
+		},
+	}
+}
+
+func (r *SpreadsheetWebhookDeliveryRepository) GetAll(ctx context.Context) ([]models.WebhookDelivery, error) {
+	rows, err := r.table.rows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		attempt, err := strconv.Atoi(row[4])
+		if err != nil {
+			return nil, err
+		}
+
+		statusCode, err := strconv.Atoi(row[5])
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, models.WebhookDelivery{
+			At:         row[0],
+			Webhook:    row[1],
+			EventID:    row[2],
+			Event:      row[3],
+			Attempt:    attempt,
+			StatusCode: statusCode,
+			Error:      row[6],
+		})
+	}
+
+	return deliveries, nil
+}
+
+func (r *SpreadsheetWebhookDeliveryRepository) Create(ctx context.Context, delivery models.WebhookDelivery) error {
+	return r.table.append(ctx, []interface{}{
+		delivery.At, delivery.Webhook, delivery.EventID, delivery.Event, delivery.Attempt, delivery.StatusCode, delivery.Error,
+	})
+}
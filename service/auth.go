@@ -0,0 +1,94 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	model "github.com/harrydrippin/go-spreadsheet-library/model"
+)
+
+const tokenIssuer = "go-spreadsheet-library"
+
+// AuthUsecase is the interface that defines the usecase for authenticating API callers
+type AuthUsecase interface {
+	IssueToken(user string) (string, time.Time, error)
+	Authenticate(token string) (model.Identity, error)
+}
+
+// AuthService issues and verifies API tokens, which are JWTs signed with HMAC so that they can be verified offline
+type AuthService struct {
+	secret []byte
+	ttl    time.Duration
+	roles  RoleResolver
+}
+
+// tokenClaims are the claims of an API token. The subject is the Slack user name of the caller.
+type tokenClaims struct {
+	Scope string `json:"scope"`
+	jwt.RegisteredClaims
+}
+
+// NewAuthService returns a new instance of AuthService
+func NewAuthService(secret string, ttl time.Duration, roles RoleResolver) *AuthService {
+	return &AuthService{secret: []byte(secret), ttl: ttl, roles: roles}
+}
+
+// IssueToken issues a token for the user with read and write scopes, and the admin scope for librarians and admins
+func (s *AuthService) IssueToken(user string) (string, time.Time, error) {
+	if len(s.secret) == 0 {
+		return "", time.Time{}, i18n.Wrap(model.ErrUnavailable, "error.auth_disabled")
+	}
+
+	scopes := []string{model.ScopeRead, model.ScopeWrite}
+	if s.roles.Role(user).Includes(model.RoleLibrarian) {
+		scopes = append(scopes, model.ScopeAdmin)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.ttl)
+	claims := tokenClaims{
+		Scope: strings.Join(scopes, " "),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    tokenIssuer,
+			Subject:   user,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// Authenticate verifies the token and returns the identity of the caller with their current role
+func (s *AuthService) Authenticate(token string) (model.Identity, error) {
+	if len(s.secret) == 0 {
+		return model.Identity{}, i18n.Wrap(model.ErrUnauthenticated, "error.auth_disabled")
+	}
+
+	claims := tokenClaims{}
+	parser := jwt.Parser{ValidMethods: []string{jwt.SigningMethodHS256.Name}}
+	_, err := parser.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	})
+	if err != nil || claims.Subject == "" || !claims.VerifyIssuer(tokenIssuer, true) {
+		return model.Identity{}, i18n.Wrap(model.ErrUnauthenticated, "error.invalid_token")
+	}
+
+	identity := model.Identity{User: claims.Subject, Role: s.roles.Role(claims.Subject), Scopes: []string{}}
+	for _, scope := range strings.Fields(claims.Scope) {
+		// 사서나 관리자에서 제외된 사용자의 토큰은 관리 권한을 잃음
+		if scope == model.ScopeAdmin && !identity.Role.Includes(model.RoleLibrarian) {
+			continue
+		}
+		identity.Scopes = append(identity.Scopes, scope)
+	}
+
+	return identity, nil
+}
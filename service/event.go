@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/harrydrippin/go-spreadsheet-library/logging"
+	model "github.com/harrydrippin/go-spreadsheet-library/model"
+)
+
+// EventPublisher is told about the domain events of the library
+type EventPublisher interface {
+	Publish(ctx context.Context, event model.DomainEvent)
+}
+
+// EventHandler handles a domain event. Its error is logged, and never reaches the use case that published the event.
+type EventHandler func(ctx context.Context, event model.DomainEvent) error
+
+type subscription struct {
+	handler EventHandler
+	events  []string
+	async   bool
+}
+
+func (s subscription) wants(event string) bool {
+	if len(s.events) == 0 {
+		return true
+	}
+
+	for _, name := range s.events {
+		if name == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EventBus publishes the domain events to the handlers subscribed to them, in the order they subscribed.
+// A handler that fails or panics does not stop the others, nor the use case that published the event.
+type EventBus struct {
+	mutex         sync.RWMutex
+	subscriptions []subscription
+}
+
+// NewEventBus returns a new instance of EventBus
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// Subscribe runs the handler before Publish returns for the events with the names, or for every event without a name.
+// Synchronous handlers should be quick, as the caller of the use case waits for them.
+func (bus *EventBus) Subscribe(handler EventHandler, events ...string) {
+	bus.subscribe(subscription{handler: handler, events: events})
+}
+
+// SubscribeAsync runs the handler in the background for the events with the names, or for every event without a name.
+// The handler gets a context that keeps the request ID and the trace of the publisher, but is not canceled when the request ends.
+func (bus *EventBus) SubscribeAsync(handler EventHandler, events ...string) {
+	bus.subscribe(subscription{handler: handler, events: events, async: true})
+}
+
+func (bus *EventBus) subscribe(s subscription) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	bus.subscriptions = append(bus.subscriptions, s)
+}
+
+// Publish hands the event to the handlers subscribed to it
+func (bus *EventBus) Publish(ctx context.Context, event model.DomainEvent) {
+	bus.mutex.RLock()
+	defer bus.mutex.RUnlock()
+
+	for _, s := range bus.subscriptions {
+		if !s.wants(event.EventName()) {
+			continue
+		}
+
+		if s.async {
+			go handle(logging.Detach(ctx), s.handler, event)
+		} else {
+			handle(ctx, s.handler, event)
+		}
+	}
+}
+
+// handle runs the handler and logs its error or panic
+func handle(ctx context.Context, handler EventHandler, event model.DomainEvent) {
+	logger := logging.FromContext(ctx).WithField("event", event.EventName())
+	defer func() {
+		if r := recover(); r != nil {
+			logger.WithField("panic", r).Error("Event handler panicked")
+		}
+	}()
+
+	if err := handler(ctx, event); err != nil {
+		logger.WithError(err).Error("Event handler failed")
+	}
+}
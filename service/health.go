@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/harrydrippin/go-spreadsheet-library/logging"
+	model "github.com/harrydrippin/go-spreadsheet-library/model"
+)
+
+// healthCheckTimeout is how long a check can take before its dependency is reported unavailable
+const healthCheckTimeout = 5 * time.Second
+
+// HealthCheck checks a dependency the library needs to serve requests
+type HealthCheck func(ctx context.Context) error
+
+// HealthUsecase is the interface that defines the usecase for the readiness of the server
+type HealthUsecase interface {
+	Readiness(ctx context.Context) model.Readiness
+}
+
+type dependency struct {
+	name  string
+	check HealthCheck
+
+	mutex  sync.Mutex
+	result model.DependencyHealth
+	expiry time.Time
+}
+
+// HealthService checks the dependencies of the library.
+// The results are cached, so that frequent probes do not use up the quota of the Sheets and Slack APIs.
+type HealthService struct {
+	ttl          time.Duration
+	dependencies []*dependency
+}
+
+// NewHealthService returns a new instance of HealthService, which checks each dependency at most once in the TTL
+func NewHealthService(ttl time.Duration) *HealthService {
+	return &HealthService{ttl: ttl}
+}
+
+// Register adds a dependency to check. It should be called before the server starts.
+func (s *HealthService) Register(name string, check HealthCheck) {
+	s.dependencies = append(s.dependencies, &dependency{name: name, check: check})
+}
+
+// Readiness checks the dependencies at the same time, and is ready only if all of them are healthy
+func (s *HealthService) Readiness(ctx context.Context) model.Readiness {
+	results := make([]model.DependencyHealth, len(s.dependencies))
+
+	var wg sync.WaitGroup
+	for i, d := range s.dependencies {
+		wg.Add(1)
+		go func(i int, d *dependency) {
+			defer wg.Done()
+			results[i] = s.health(ctx, d)
+		}(i, d)
+	}
+	wg.Wait()
+
+	readiness := model.Readiness{Status: model.HealthOK, Dependencies: make(map[string]model.DependencyHealth)}
+	for i, d := range s.dependencies {
+		readiness.Dependencies[d.name] = results[i]
+		if results[i].Status != model.HealthOK {
+			readiness.Status = model.HealthUnavailable
+		}
+	}
+
+	return readiness
+}
+
+// health returns the cached result of the dependency, checking it again if the result has expired.
+// Probes arriving during a check wait for it instead of checking again.
+func (s *HealthService) health(ctx context.Context, d *dependency) model.DependencyHealth {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if time.Now().Before(d.expiry) {
+		return d.result
+	}
+
+	// 요청이 취소되어도 확인 결과는 캐시되므로, 요청과 상관없이 제한 시간까지 확인함
+	checkCtx, cancel := context.WithTimeout(logging.Detach(ctx), healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := runCheck(checkCtx, d.check)
+	d.result = model.DependencyHealth{
+		Status:    model.HealthOK,
+		CheckedAt: start.Format(time.RFC3339),
+		Duration:  time.Since(start).Seconds(),
+	}
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).WithField("dependency", d.name).Warn("Health check failed")
+		d.result.Status = model.HealthUnavailable
+		d.result.Error = healthError(err)
+	}
+	d.expiry = time.Now().Add(s.ttl)
+
+	return d.result
+}
+
+// healthError returns the kind of failure of a check, without details such as the URL of the request
+func healthError(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return model.HealthErrorTimeout
+	}
+
+	return model.HealthErrorFailed
+}
+
+// runCheck returns the error of the check, or of the context if the check does not return in time
+func runCheck(ctx context.Context, check HealthCheck) error {
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("health check panicked: %v", r)
+			}
+		}()
+		done <- check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
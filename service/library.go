@@ -1,91 +1,395 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	"github.com/harrydrippin/go-spreadsheet-library/logging"
 	model "github.com/harrydrippin/go-spreadsheet-library/model"
 	repositories "github.com/harrydrippin/go-spreadsheet-library/repository"
+	"github.com/sirupsen/logrus"
 )
 
 // LibraryUsecase is the interface that defines the usecase for the library
 type LibraryUsecase interface {
-	Search(title string) ([]model.Book, error)
-	SearchById(id int) (model.Book, error)
-	Borrow(book model.Book, borrower string) (model.Book, error)
-	Return(book model.Book, borrower string) (model.Book, error)
-	Extend(book model.Book, borrower string) (model.Book, error)
-	Status(borrower string) ([]model.Book, error)
+	List(ctx context.Context) ([]model.Book, error)
+	Search(ctx context.Context, title string) ([]model.Book, error)
+	SearchById(ctx context.Context, id int) (model.Book, error)
+	Borrow(ctx context.Context, book model.Book, borrower string) (model.Book, error)
+	Return(ctx context.Context, book model.Book, borrower string) (model.Book, error)
+	Extend(ctx context.Context, book model.Book, borrower string) (model.Book, error)
+	Status(ctx context.Context, borrower string) ([]model.Book, error)
+	SearchAll(ctx context.Context, title string) ([]model.Book, error)
+	ChangeStatus(ctx context.Context, book model.Book, status model.Status, admin string) (model.Book, error)
+	ForceReturn(ctx context.Context, book model.Book, librarian string) (model.Book, error)
+	ChangeDueDate(ctx context.Context, book model.Book, dueDate string, librarian string) (model.Book, error)
+	EditDetails(ctx context.Context, book model.Book, details model.BookDetails, librarian string) (model.Book, error)
+	Delete(ctx context.Context, book model.Book, admin string) error
+	AddBook(ctx context.Context, details model.BookDetails, admin string) (model.Book, error)
+	Archive(ctx context.Context, book model.Book, admin string) (model.Book, error)
+	Reassign(ctx context.Context, book model.Book, borrower string, librarian string) (model.Book, error)
+	AuditTrail(ctx context.Context, bookId int, librarian string) ([]model.AuditEntry, error)
+	LostBooks(ctx context.Context, librarian string) (map[string][]model.Book, error)
+	MarkOverdue(ctx context.Context) ([]model.Book, error)
+	Loans(ctx context.Context, borrower string) ([]model.Loan, error)
 }
 
-// LibraryService is the service that handles the library usecase
+// statusTransitions lists the statuses a librarian can change a book into from each status
+var statusTransitions = map[model.Status][]model.Status{
+	model.StatusInOffice:  {model.StatusLost, model.StatusDamaged, model.StatusWithdrawn},
+	model.StatusBorrowed:  {model.StatusLost, model.StatusDamaged},
+	model.StatusOverdue:   {model.StatusLost, model.StatusDamaged},
+	model.StatusLost:      {model.StatusInOffice, model.StatusWithdrawn},
+	model.StatusDamaged:   {model.StatusRepairing, model.StatusWithdrawn},
+	model.StatusRepairing: {model.StatusInOffice, model.StatusWithdrawn},
+}
+
+// LibraryService is the service that handles the library usecase.
+// Every change made by a librarian or an admin is recorded in the audit trail, and domain events are published.
 type LibraryService struct {
-	repository repositories.BookRepository
+	repository      repositories.BookRepository
+	loanRepository  repositories.LoanRepository
+	auditRepository repositories.AuditRepository
+	roles           RoleResolver
+	events          EventPublisher
+
+	// createMutex serializes adding books, as the repository numbers a new book after the rows it read
+	createMutex sync.Mutex
 }
 
 // NewLibraryService returns a new instance of LibraryService
-func NewLibraryService(repository repositories.BookRepository) *LibraryService {
-	return &LibraryService{repository: repository}
+func NewLibraryService(repository repositories.BookRepository, loanRepository repositories.LoanRepository, auditRepository repositories.AuditRepository, roles RoleResolver, events EventPublisher) *LibraryService {
+	return &LibraryService{repository: repository, loanRepository: loanRepository, auditRepository: auditRepository, roles: roles, events: events}
 }
 
-func (library *LibraryService) Search(title string) ([]model.Book, error) {
-	return library.repository.SearchByTitle(title)
+func (library *LibraryService) List(ctx context.Context) ([]model.Book, error) {
+	return library.repository.GetAll(ctx)
 }
 
-func (library *LibraryService) SearchById(id int) (model.Book, error) {
-	return library.repository.SearchById(id)
+// Search returns the books matching the title, except the ones out of circulation
+func (library *LibraryService) Search(ctx context.Context, title string) ([]model.Book, error) {
+	books, err := library.repository.SearchByTitle(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []model.Book{}
+	for _, book := range books {
+		if book.IsInCirculation() {
+			result = append(result, book)
+		}
+	}
+
+	return result, nil
+}
+
+// SearchAll returns every book matching the title, including lost, damaged and withdrawn ones
+func (library *LibraryService) SearchAll(ctx context.Context, title string) ([]model.Book, error) {
+	return library.repository.SearchByTitle(ctx, title)
+}
+
+func (library *LibraryService) SearchById(ctx context.Context, id int) (model.Book, error) {
+	return library.repository.SearchById(ctx, id)
 }
 
-func (library *LibraryService) Borrow(book model.Book, borrower string) (model.Book, error) {
+func (library *LibraryService) Borrow(ctx context.Context, book model.Book, borrower string) (model.Book, error) {
 	if book.Status != model.StatusInOffice {
-		return model.Book{}, errors.New("이미 대출되어 있는 책이에요. 다시 확인해주세요.")
+		return model.Book{}, i18n.Wrap(model.ErrAlreadyBorrowed, "error.already_borrowed")
 	}
 
 	// 4주 뒤 반납 예정인 대출된 책으로 변경
 	book.Status = model.StatusBorrowed
 	book.Borrower = borrower
 	book.DueDate = time.Now().AddDate(0, 0, 28).Format("2006-01-02")
-	err := library.repository.Update(book)
+	err := library.repository.Update(ctx, book)
+	if err != nil {
+		return book, err
+	}
+
+	// 추천 등에 사용할 대출 기록을 남김
+	library.openLoan(ctx, book.ID, borrower, time.Now().Format("2006-01-02"))
 
-	return book, err
+	library.events.Publish(ctx, model.BookBorrowed{Book: book})
+	return book, nil
 }
 
-func (library *LibraryService) Return(book model.Book, borrower string) (model.Book, error) {
-	if book.Status == model.StatusInOffice {
-		return model.Book{}, errors.New("대출된 책이 아니에요! 다시 확인해주세요.")
+// Return returns the book for the borrower. A lost or damaged book is not returned this way,
+// as only a librarian can change its status.
+func (library *LibraryService) Return(ctx context.Context, book model.Book, borrower string) (model.Book, error) {
+	if book.Status != model.StatusBorrowed && book.Status != model.StatusOverdue {
+		return model.Book{}, i18n.Wrap(model.ErrNotBorrowed, "error.not_borrowed")
 	}
 
 	if book.Borrower != borrower {
-		return model.Book{}, errors.New("@" + borrower + " 님이 대출하신 책이 아니에요. 다시 확인해주세요!")
+		return model.Book{}, i18n.Wrap(model.ErrNotBorrower, "error.not_borrower", borrower)
 	}
 
+	return library.returnBook(ctx, book)
+}
+
+// ForceReturn returns the book for its borrower, such as someone who left the company without returning it
+func (library *LibraryService) ForceReturn(ctx context.Context, book model.Book, librarian string) (model.Book, error) {
+	if err := requireRole(library.roles, librarian, model.RoleLibrarian); err != nil {
+		return model.Book{}, err
+	}
+
+	if book.Status != model.StatusBorrowed && book.Status != model.StatusOverdue {
+		return model.Book{}, i18n.Wrap(model.ErrNotBorrowed, "error.not_borrowed")
+	}
+
+	borrower := book.Borrower
+	book, err := library.returnBook(ctx, book)
+	if err != nil {
+		return book, err
+	}
+
+	library.record(ctx, librarian, model.AuditForceReturn, book.ID, borrower)
+	return book, nil
+}
+
+func (library *LibraryService) returnBook(ctx context.Context, book model.Book) (model.Book, error) {
+	borrower := book.Borrower
+
 	// 반납된 책으로 변경
 	book.Status = model.StatusInOffice
 	book.Borrower = ""
 	book.DueDate = ""
-	err := library.repository.Update(book)
+	err := library.repository.Update(ctx, book)
+	if err != nil {
+		return book, err
+	}
+
+	library.closeLoan(ctx, book.ID, borrower, time.Now().Format("2006-01-02"))
 
-	return book, err
+	library.events.Publish(ctx, model.BookReturned{Book: book, Borrower: borrower})
+	return book, nil
 }
 
-func (library *LibraryService) Extend(book model.Book, borrower string) (model.Book, error) {
-	if book.Status != model.StatusBorrowed {
-		return model.Book{}, errors.New("이 책은 대출된 상태가 아니에요. 다시 확인해주세요.")
+// Extend pushes the due date of the loan back by 4 weeks. An overdue book can be extended too, and becomes borrowed again.
+func (library *LibraryService) Extend(ctx context.Context, book model.Book, borrower string) (model.Book, error) {
+	if book.Status != model.StatusBorrowed && book.Status != model.StatusOverdue {
+		return model.Book{}, i18n.Wrap(model.ErrNotBorrowed, "error.not_extendable")
 	}
 
 	if book.Borrower != borrower {
-		return model.Book{}, errors.New("@" + borrower + " 님이 대출하신 책이 아니에요. 다시 확인해주세요!")
+		return model.Book{}, i18n.Wrap(model.ErrNotBorrower, "error.not_borrower", borrower)
 	}
 
 	// 대출 기한을 연장
+	previousDueDate := book.DueDate
+	book.Status = model.StatusBorrowed
 	book.DueDate = time.Now().AddDate(0, 0, 28).Format("2006-01-02")
-	err := library.repository.Update(book)
+	err := library.repository.Update(ctx, book)
+	if err != nil {
+		return book, err
+	}
 
-	return book, err
+	library.events.Publish(ctx, model.LoanExtended{Book: book, PreviousDueDate: previousDueDate})
+	return book, nil
 }
 
-func (library *LibraryService) Status(borrower string) ([]model.Book, error) {
-	books, err := library.repository.GetAll()
+// MarkOverdue changes the borrowed books past their due date into overdue and returns them.
+// Each book is read again right before it is changed, so that a book returned or extended during the sweep is left as it is.
+func (library *LibraryService) MarkOverdue(ctx context.Context) ([]model.Book, error) {
+	books, err := library.repository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Now().Format("2006-01-02")
+	result := []model.Book{}
+	for _, book := range books {
+		if book.Status != model.StatusBorrowed || !book.IsOverdue(today) {
+			continue
+		}
+
+		// 목록을 읽은 뒤 바뀌었을 수 있으므로 최신 행을 다시 읽음
+		book, err = library.repository.SearchById(ctx, book.ID)
+		if err != nil {
+			return result, err
+		}
+		if book.Status != model.StatusBorrowed || !book.IsOverdue(today) {
+			continue
+		}
+
+		book.Status = model.StatusOverdue
+		if err := library.repository.Update(ctx, book); err != nil {
+			return result, err
+		}
+
+		library.events.Publish(ctx, model.BookOverdue{Book: book})
+		result = append(result, book)
+	}
+
+	return result, nil
+}
+
+// ChangeDueDate sets the due date of a borrowed book to the date in YYYY-MM-DD
+func (library *LibraryService) ChangeDueDate(ctx context.Context, book model.Book, dueDate string, librarian string) (model.Book, error) {
+	if err := requireRole(library.roles, librarian, model.RoleLibrarian); err != nil {
+		return model.Book{}, err
+	}
+
+	if book.Status != model.StatusBorrowed && book.Status != model.StatusOverdue {
+		return model.Book{}, i18n.Wrap(model.ErrNotBorrowed, "error.not_borrowed")
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", dueDate, time.Local)
+	if err != nil {
+		return model.Book{}, i18n.Wrap(model.ErrInvalidInput, "error.invalid_date", dueDate)
+	}
+
+	// 연체된 책의 기한을 늦춰주면 다시 대출 상태로 돌아감
+	previous := book.DueDate
+	book.DueDate = date.Format("2006-01-02")
+	if book.Status == model.StatusOverdue && book.DueDate >= time.Now().Format("2006-01-02") {
+		book.Status = model.StatusBorrowed
+	}
+	err = library.repository.Update(ctx, book)
+	if err != nil {
+		return book, err
+	}
+
+	library.record(ctx, librarian, model.AuditDueDate, book.ID, fmt.Sprintf("%s → %s", previous, book.DueDate))
+	return book, nil
+}
+
+// Reassign hands a borrowed book over to another borrower, keeping its due date
+func (library *LibraryService) Reassign(ctx context.Context, book model.Book, borrower string, librarian string) (model.Book, error) {
+	if err := requireRole(library.roles, librarian, model.RoleLibrarian); err != nil {
+		return model.Book{}, err
+	}
+
+	if book.Status != model.StatusBorrowed && book.Status != model.StatusOverdue {
+		return model.Book{}, i18n.Wrap(model.ErrNotBorrowed, "error.not_borrowed")
+	}
+
+	if borrower == "" {
+		return model.Book{}, i18n.Wrap(model.ErrInvalidInput, "error.user_required")
+	}
+
+	previous := book.Borrower
+	book.Borrower = borrower
+	err := library.repository.Update(ctx, book)
+	if err != nil {
+		return book, err
+	}
+
+	// 이전 대출자의 대출 기록을 마무리하고 새 대출자의 기록을 남김
+	today := time.Now().Format("2006-01-02")
+	library.closeLoan(ctx, book.ID, previous, today)
+	library.openLoan(ctx, book.ID, borrower, today)
+
+	library.record(ctx, librarian, model.AuditReassign, book.ID, fmt.Sprintf("%s → %s", previous, borrower))
+	return book, nil
+}
+
+// AddBook adds a new book to the library, available to borrow
+func (library *LibraryService) AddBook(ctx context.Context, details model.BookDetails, admin string) (model.Book, error) {
+	if err := requireRole(library.roles, admin, model.RoleAdmin); err != nil {
+		return model.Book{}, err
+	}
+
+	if strings.TrimSpace(details.Title) == "" {
+		return model.Book{}, i18n.Wrap(model.ErrInvalidInput, "error.book_title_required")
+	}
+
+	book := model.Book{
+		Title:     strings.TrimSpace(details.Title),
+		Author:    details.Author,
+		Publisher: details.Publisher,
+		Position:  details.Position,
+		Status:    model.StatusInOffice,
+	}
+
+	library.createMutex.Lock()
+	book, err := library.repository.Create(ctx, book)
+	library.createMutex.Unlock()
+	if err != nil {
+		return book, err
+	}
+
+	library.record(ctx, admin, model.AuditCreate, book.ID, book.Title)
+	return book, nil
+}
+
+// Archive withdraws the book from circulation while keeping it in the catalog
+func (library *LibraryService) Archive(ctx context.Context, book model.Book, admin string) (model.Book, error) {
+	if err := requireRole(library.roles, admin, model.RoleAdmin); err != nil {
+		return model.Book{}, err
+	}
+
+	return library.changeStatus(ctx, book, model.StatusWithdrawn, admin, model.AuditArchive)
+}
+
+// EditDetails changes the title, author, publisher or position of the book
+func (library *LibraryService) EditDetails(ctx context.Context, book model.Book, details model.BookDetails, librarian string) (model.Book, error) {
+	if err := requireRole(library.roles, librarian, model.RoleLibrarian); err != nil {
+		return model.Book{}, err
+	}
+
+	if details.Title != "" {
+		book.Title = details.Title
+	}
+	if details.Author != "" {
+		book.Author = details.Author
+	}
+	if details.Publisher != "" {
+		book.Publisher = details.Publisher
+	}
+	if details.Position != "" {
+		book.Position = details.Position
+	}
+	err := library.repository.Update(ctx, book)
+	if err != nil {
+		return book, err
+	}
+
+	library.record(ctx, librarian, model.AuditEdit, book.ID, describeDetails(details))
+	return book, nil
+}
+
+// describeDetails describes the edited details for the audit trail
+func describeDetails(details model.BookDetails) string {
+	changes := []string{}
+	for _, field := range []struct{ name, value string }{
+		{"title", details.Title},
+		{"author", details.Author},
+		{"publisher", details.Publisher},
+		{"position", details.Position},
+	} {
+		if field.value != "" {
+			changes = append(changes, fmt.Sprintf("%s=%s", field.name, field.value))
+		}
+	}
+
+	return strings.Join(changes, ", ")
+}
+
+// Delete removes the book from the library. Borrowed books must be returned first.
+func (library *LibraryService) Delete(ctx context.Context, book model.Book, admin string) error {
+	if err := requireRole(library.roles, admin, model.RoleAdmin); err != nil {
+		return err
+	}
+
+	if book.Borrower != "" {
+		return i18n.Wrap(model.ErrConflict, "error.delete_borrowed", book.Borrower)
+	}
+
+	if err := library.repository.Delete(ctx, book.ID); err != nil {
+		return err
+	}
+
+	library.record(ctx, admin, model.AuditDelete, book.ID, book.Title)
+	return nil
+}
+
+func (library *LibraryService) Status(ctx context.Context, borrower string) ([]model.Book, error) {
+	books, err := library.repository.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -99,3 +403,129 @@ func (library *LibraryService) Status(borrower string) ([]model.Book, error) {
 
 	return result, nil
 }
+
+func (library *LibraryService) ChangeStatus(ctx context.Context, book model.Book, status model.Status, admin string) (model.Book, error) {
+	if err := requireRole(library.roles, admin, model.RoleLibrarian); err != nil {
+		return model.Book{}, err
+	}
+
+	return library.changeStatus(ctx, book, status, admin, model.AuditStatus)
+}
+
+func (library *LibraryService) changeStatus(ctx context.Context, book model.Book, status model.Status, actor string, action string) (model.Book, error) {
+	allowed := false
+	for _, next := range statusTransitions[book.Status] {
+		if next == status {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return model.Book{}, i18n.Wrap(model.ErrConflict, "error.invalid_status_transition", book.Status, status)
+	}
+
+	borrower := book.Borrower
+	if status == model.StatusLost {
+		// 대출 중 분실된 책은 분실 현황을 위해 대출자를 남겨둠
+		book.DueDate = ""
+	} else {
+		book.Borrower = ""
+		book.DueDate = ""
+	}
+
+	previous := book.Status
+	book.Status = status
+	err := library.repository.Update(ctx, book)
+	if err != nil {
+		return book, err
+	}
+
+	// 대출 중 파손되었거나 분실되었던 책을 찾은 경우 대출 기록을 마무리함
+	if status != model.StatusLost && borrower != "" {
+		library.closeLoan(ctx, book.ID, borrower, time.Now().Format("2006-01-02"))
+	}
+
+	library.record(ctx, actor, action, book.ID, fmt.Sprintf("%s → %s", previous, status))
+
+	library.events.Publish(ctx, model.BookStatusChanged{Book: book, Previous: previous, Actor: actor})
+	return book, nil
+}
+
+// LostBooks returns the lost books grouped by the borrower who lost them. Books lost from the shelf have an empty borrower.
+// Only librarians can see it, as it names the borrowers.
+func (library *LibraryService) LostBooks(ctx context.Context, librarian string) (map[string][]model.Book, error) {
+	if err := requireRole(library.roles, librarian, model.RoleLibrarian); err != nil {
+		return nil, err
+	}
+
+	books, err := library.repository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]model.Book)
+	for _, book := range books {
+		if book.Status == model.StatusLost {
+			result[book.Borrower] = append(result[book.Borrower], book)
+		}
+	}
+
+	return result, nil
+}
+
+// Loans returns the loan history of the borrower, oldest first
+func (library *LibraryService) Loans(ctx context.Context, borrower string) ([]model.Loan, error) {
+	return library.loanRepository.SearchByBorrower(ctx, borrower)
+}
+
+// AuditTrail returns the changes made to the book by librarians and admins, or to every book with the ID 0, oldest first
+func (library *LibraryService) AuditTrail(ctx context.Context, bookId int, librarian string) ([]model.AuditEntry, error) {
+	if err := requireRole(library.roles, librarian, model.RoleLibrarian); err != nil {
+		return nil, err
+	}
+
+	entries, err := library.auditRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []model.AuditEntry{}
+	for _, entry := range entries {
+		if bookId == 0 || entry.BookID == bookId {
+			result = append(result, entry)
+		}
+	}
+
+	return result, nil
+}
+
+// openLoan starts the loan of the book in the loan history, which recommendations are made from.
+// The book has been saved by then, so a failure is logged instead of being returned:
+// the user would retry a borrow that already happened and be told that the book is borrowed.
+func (library *LibraryService) openLoan(ctx context.Context, bookId int, borrower string, date string) {
+	loan := model.Loan{BookID: bookId, Borrower: borrower, BorrowedAt: date}
+	if err := library.loanRepository.Create(ctx, loan); err != nil {
+		logging.FromContext(ctx).WithError(err).WithField("book", bookId).Error("Unable to record the loan")
+	}
+}
+
+// closeLoan ends the loan of the book by the borrower in the loan history. Like openLoan, a failure is only logged.
+func (library *LibraryService) closeLoan(ctx context.Context, bookId int, borrower string, date string) {
+	if err := library.loanRepository.Close(ctx, bookId, borrower, date); err != nil {
+		logging.FromContext(ctx).WithError(err).WithField("book", bookId).Error("Unable to close the loan")
+	}
+}
+
+// record adds the change to the audit trail. The change has been saved by then, so a failure is logged instead of
+// being returned, which would make the librarian retry a change that already happened.
+func (library *LibraryService) record(ctx context.Context, actor string, action string, bookId int, detail string) {
+	entry := model.AuditEntry{
+		At:     time.Now().Format("2006-01-02 15:04"),
+		Actor:  actor,
+		Action: action,
+		BookID: bookId,
+		Detail: detail,
+	}
+	if err := library.auditRepository.Create(ctx, entry); err != nil {
+		logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{"book": bookId, "action": action}).Error("Unable to record the audit entry")
+	}
+}
@@ -0,0 +1,8 @@
+package service
+
+import "context"
+
+// Notifier sends a direct message to a library user, rendered from the message catalog in the user's locale
+type Notifier interface {
+	Notify(ctx context.Context, user string, key string, args ...interface{}) error
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	"github.com/harrydrippin/go-spreadsheet-library/logging"
+	model "github.com/harrydrippin/go-spreadsheet-library/model"
+	repositories "github.com/harrydrippin/go-spreadsheet-library/repository"
+)
+
+// PreferenceUsecase is the interface that defines the usecase for user preferences
+type PreferenceUsecase interface {
+	Locale(ctx context.Context, user string) (string, bool)
+	SetLocale(ctx context.Context, user string, locale string) (string, error)
+}
+
+// PreferenceService is the service that handles the preference usecase.
+// Preferences are read on every command, so they are cached after the first read.
+type PreferenceService struct {
+	repository repositories.PreferenceRepository
+
+	mutex   sync.Mutex
+	locales map[string]string
+}
+
+// NewPreferenceService returns a new instance of PreferenceService
+func NewPreferenceService(repository repositories.PreferenceRepository) *PreferenceService {
+	return &PreferenceService{repository: repository}
+}
+
+// Locale returns the locale the user chose, if any
+func (s *PreferenceService) Locale(ctx context.Context, user string) (string, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.load(ctx); err != nil {
+		// 설정을 읽지 못하면 Slack 계정의 언어를 사용하고, 다음 요청에서 다시 읽음
+		logging.FromContext(ctx).WithError(err).Error("Unable to load the locale preferences")
+		return "", false
+	}
+
+	locale, ok := s.locales[user]
+	return locale, ok
+}
+
+// Warm reads the preferences into the cache if they have not been read yet, so that the first command is not slowed down
+func (s *PreferenceService) Warm(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.load(ctx)
+}
+
+// load reads the preferences unless they are cached. The mutex must be held.
+func (s *PreferenceService) load(ctx context.Context) error {
+	if s.locales != nil {
+		return nil
+	}
+
+	locales, err := s.repository.GetLocales(ctx)
+	if err != nil {
+		return err
+	}
+	s.locales = locales
+
+	return nil
+}
+
+func (s *PreferenceService) SetLocale(ctx context.Context, user string, tag string) (string, error) {
+	locale, ok := i18n.Parse(tag)
+	if !ok {
+		return "", i18n.Wrap(model.ErrInvalidInput, "error.unsupported_locale", tag)
+	}
+
+	if err := s.repository.SetLocale(ctx, user, locale); err != nil {
+		return "", err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.locales != nil {
+		s.locales[user] = locale
+	}
+
+	return locale, nil
+}
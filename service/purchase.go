@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	"github.com/harrydrippin/go-spreadsheet-library/logging"
+	model "github.com/harrydrippin/go-spreadsheet-library/model"
+	repositories "github.com/harrydrippin/go-spreadsheet-library/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// PurchaseRequestUsecase is the interface that defines the usecase for book purchase requests
+type PurchaseRequestUsecase interface {
+	Submit(ctx context.Context, title, author, requester string) (model.PurchaseRequest, error)
+	List(ctx context.Context) ([]model.PurchaseRequest, error)
+	Vote(ctx context.Context, id int, voter string) (model.PurchaseRequest, error)
+	Approve(ctx context.Context, id int, admin string) (model.PurchaseRequest, error)
+	Reject(ctx context.Context, id int, admin, reason string) (model.PurchaseRequest, error)
+	MarkPurchased(ctx context.Context, id int, admin string) (model.PurchaseRequest, error)
+	NotifyStocked(ctx context.Context) error
+}
+
+// PurchaseRequestService is the service that handles the purchase request usecase.
+// The sheet has no transactions, so writes are serialized: the repository numbers new requests
+// after the rows it read, and a vote rewrites the voters it read.
+type PurchaseRequestService struct {
+	repository     repositories.PurchaseRequestRepository
+	bookRepository repositories.BookRepository
+	notifier       Notifier
+	roles          RoleResolver
+
+	mutex sync.Mutex
+}
+
+// NewPurchaseRequestService returns a new instance of PurchaseRequestService
+func NewPurchaseRequestService(repository repositories.PurchaseRequestRepository, bookRepository repositories.BookRepository, notifier Notifier, roles RoleResolver) *PurchaseRequestService {
+	return &PurchaseRequestService{
+		repository:     repository,
+		bookRepository: bookRepository,
+		notifier:       notifier,
+		roles:          roles,
+	}
+}
+
+// normalizeTitle makes titles comparable regardless of spacing and case
+func normalizeTitle(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), ""))
+}
+
+func (s *PurchaseRequestService) Submit(ctx context.Context, title, author, requester string) (model.PurchaseRequest, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return model.PurchaseRequest{}, i18n.Wrap(model.ErrInvalidInput, "error.request_title_required")
+	}
+
+	books, err := s.bookRepository.GetAll(ctx)
+	if err != nil {
+		return model.PurchaseRequest{}, err
+	}
+
+	for _, book := range books {
+		if normalizeTitle(book.Title) == normalizeTitle(title) {
+			return model.PurchaseRequest{}, i18n.Wrap(model.ErrConflict, "error.request_in_library")
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	requests, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return model.PurchaseRequest{}, err
+	}
+
+	for _, request := range requests {
+		if request.IsOpen() && normalizeTitle(request.Title) == normalizeTitle(title) {
+			return model.PurchaseRequest{}, i18n.Wrap(model.ErrConflict, "error.request_duplicate", request.ID)
+		}
+	}
+
+	request := model.PurchaseRequest{
+		Title:     title,
+		Author:    strings.TrimSpace(author),
+		Requester: requester,
+		Voters:    []string{},
+		Status:    model.RequestStatusPending,
+		CreatedAt: time.Now().Format("2006-01-02"),
+	}
+
+	return s.repository.Create(ctx, request)
+}
+
+// List returns the open purchase requests, most voted first
+func (s *PurchaseRequestService) List(ctx context.Context) ([]model.PurchaseRequest, error) {
+	requests, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []model.PurchaseRequest{}
+	for _, request := range requests {
+		if request.IsOpen() {
+			result = append(result, request)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Votes() > result[j].Votes()
+	})
+
+	return result, nil
+}
+
+func (s *PurchaseRequestService) Vote(ctx context.Context, id int, voter string) (model.PurchaseRequest, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	request, err := s.repository.SearchById(ctx, id)
+	if err != nil {
+		return model.PurchaseRequest{}, err
+	}
+
+	if request.Status != model.RequestStatusPending {
+		return model.PurchaseRequest{}, i18n.Wrap(model.ErrConflict, "error.request_closed")
+	}
+
+	if request.HasVoted(voter) {
+		return model.PurchaseRequest{}, i18n.Wrap(model.ErrConflict, "error.request_voted")
+	}
+
+	request.Voters = append(request.Voters, voter)
+	err = s.repository.Update(ctx, request)
+
+	return request, err
+}
+
+func (s *PurchaseRequestService) Approve(ctx context.Context, id int, admin string) (model.PurchaseRequest, error) {
+	return s.transition(ctx, id, admin, model.RequestStatusPending, model.RequestStatusApproved, "")
+}
+
+func (s *PurchaseRequestService) Reject(ctx context.Context, id int, admin, reason string) (model.PurchaseRequest, error) {
+	return s.transition(ctx, id, admin, model.RequestStatusPending, model.RequestStatusRejected, reason)
+}
+
+func (s *PurchaseRequestService) MarkPurchased(ctx context.Context, id int, admin string) (model.PurchaseRequest, error) {
+	return s.transition(ctx, id, admin, model.RequestStatusApproved, model.RequestStatusPurchased, "")
+}
+
+func (s *PurchaseRequestService) transition(ctx context.Context, id int, admin string, from, to model.RequestStatus, memo string) (model.PurchaseRequest, error) {
+	if err := requireRole(s.roles, admin, model.RoleLibrarian); err != nil {
+		return model.PurchaseRequest{}, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	request, err := s.repository.SearchById(ctx, id)
+	if err != nil {
+		return model.PurchaseRequest{}, err
+	}
+
+	if request.Status != from {
+		return model.PurchaseRequest{}, i18n.Wrap(model.ErrConflict, "error.request_invalid_transition", from, to, request.Status)
+	}
+
+	request.Status = to
+	if memo != "" {
+		request.Memo = memo
+	}
+	err = s.repository.Update(ctx, request)
+
+	return request, err
+}
+
+// NotifyStocked finds purchased requests whose book has been added to the catalog,
+// notifies the requester and voters, and marks them as stocked.
+func (s *PurchaseRequestService) NotifyStocked(ctx context.Context) error {
+	stocked, err := s.markStocked(ctx)
+
+	var notifyErr error
+	for _, request := range stocked {
+		for _, user := range append([]string{request.Requester}, request.Voters...) {
+			// 한 명에게 실패하더라도 나머지에게는 알림을 보냄
+			if err := s.notifier.Notify(ctx, user, "notify.request_stocked", request.book.Title, request.book.Position, request.book.Title); err != nil {
+				logging.FromContext(ctx).WithError(err).WithFields(logrus.Fields{"user": user, "purchase_request": request.ID}).Error("Unable to notify that the book is stocked")
+				if notifyErr == nil {
+					notifyErr = err
+				}
+			}
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return notifyErr
+}
+
+// stockedRequest is a purchase request marked as stocked, with the book that was added for it
+type stockedRequest struct {
+	model.PurchaseRequest
+	book model.Book
+}
+
+// markStocked marks the purchased requests as stocked once a book with the same title, ignoring spacing and case, is in the catalog.
+// It returns the requests it marked, even if it failed to mark the rest, so that they are still notified.
+func (s *PurchaseRequestService) markStocked(ctx context.Context) ([]stockedRequest, error) {
+	// 알림은 느리므로 잠금을 풀고 보냄
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	requests, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	books, err := s.bookRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stocked := []stockedRequest{}
+	for _, request := range requests {
+		if request.Status != model.RequestStatusPurchased {
+			continue
+		}
+
+		for _, book := range books {
+			// 짧은 제목이 다른 책의 제목에 포함되어 잘못 입고 처리되지 않도록 제목 전체를 비교함
+			if normalizeTitle(book.Title) != normalizeTitle(request.Title) {
+				continue
+			}
+
+			request.Status = model.RequestStatusStocked
+			if err := s.repository.Update(ctx, request); err != nil {
+				return stocked, err
+			}
+
+			stocked = append(stocked, stockedRequest{PurchaseRequest: request, book: book})
+			break
+		}
+	}
+
+	return stocked, nil
+}
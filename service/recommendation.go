@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+
+	model "github.com/harrydrippin/go-spreadsheet-library/model"
+	repositories "github.com/harrydrippin/go-spreadsheet-library/repository"
+)
+
+// Weights of each signal in the recommendation score
+const (
+	coBorrowWeight     = 1.0
+	authorWeight       = 0.5
+	categoryWeight     = 0.3
+	availabilityWeight = 0.2
+)
+
+// RecommendationUsecase is the interface that defines the usecase for book recommendations
+type RecommendationUsecase interface {
+	Recommend(ctx context.Context, user string, limit int) ([]model.Recommendation, error)
+}
+
+// RecommendationService recommends books from the loan history of every user
+type RecommendationService struct {
+	repository     repositories.BookRepository
+	loanRepository repositories.LoanRepository
+}
+
+// NewRecommendationService returns a new instance of RecommendationService
+func NewRecommendationService(repository repositories.BookRepository, loanRepository repositories.LoanRepository) *RecommendationService {
+	return &RecommendationService{repository: repository, loanRepository: loanRepository}
+}
+
+// Recommend scores every book the user hasn't read by how often it was borrowed together with the user's books
+// (item-based cosine similarity), the user's affinity to its author and position, and whether it is available now.
+// Users without any history get the most borrowed books.
+func (s *RecommendationService) Recommend(ctx context.Context, user string, limit int) ([]model.Recommendation, error) {
+	books, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loans, err := s.loanRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 책마다 읽은 사람, 사용자가 읽은 책
+	readers := make(map[int]map[string]bool)
+	read := make(map[int]bool)
+	for _, loan := range loans {
+		if readers[loan.BookID] == nil {
+			readers[loan.BookID] = make(map[string]bool)
+		}
+		readers[loan.BookID][loan.Borrower] = true
+
+		if loan.Borrower == user {
+			read[loan.BookID] = true
+		}
+	}
+
+	booksById := make(map[int]model.Book)
+	for _, book := range books {
+		booksById[book.ID] = book
+		if book.Borrower == user {
+			read[book.ID] = true
+		}
+	}
+
+	// 사용자가 자주 읽은 저자와 분야(위치)
+	authors := make(map[string]float64)
+	categories := make(map[string]float64)
+	for bookId := range read {
+		book, ok := booksById[bookId]
+		if !ok {
+			continue
+		}
+		if book.Author != "" {
+			authors[book.Author] += 1 / float64(len(read))
+		}
+		if book.Position != "" {
+			categories[book.Position] += 1 / float64(len(read))
+		}
+	}
+
+	recommendations := []model.Recommendation{}
+	for _, book := range books {
+		if read[book.ID] || !book.IsInCirculation() {
+			continue
+		}
+
+		coBorrow := 0.0
+		for bookId := range read {
+			coBorrow += cosineSimilarity(readers[bookId], readers[book.ID])
+		}
+
+		score := coBorrowWeight*coBorrow + authorWeight*authors[book.Author] + categoryWeight*categories[book.Position]
+		reason, detail := "", ""
+		switch {
+		case coBorrow > 0 && coBorrowWeight*coBorrow >= authorWeight*authors[book.Author]:
+			reason = model.ReasonCoBorrowed
+		case authors[book.Author] > 0:
+			reason, detail = model.ReasonAuthor, book.Author
+		case categories[book.Position] > 0:
+			reason, detail = model.ReasonCategory, book.Position
+		case len(read) == 0 && len(readers[book.ID]) > 0:
+			// 대출 기록이 없는 사용자에게는 인기 있는 책을 추천
+			score = float64(len(readers[book.ID])) / float64(len(loans))
+			reason, detail = model.ReasonPopular, strconv.Itoa(len(readers[book.ID]))
+		default:
+			continue
+		}
+
+		if book.Status == model.StatusInOffice {
+			score += availabilityWeight * score
+		}
+
+		recommendations = append(recommendations, model.Recommendation{Book: book, Score: score, Reason: reason, ReasonDetail: detail})
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].Score > recommendations[j].Score
+	})
+
+	if limit > 0 && len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+
+	return recommendations, nil
+}
+
+func cosineSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	common := 0
+	for user := range a {
+		if b[user] {
+			common++
+		}
+	}
+
+	return float64(common) / math.Sqrt(float64(len(a)*len(b)))
+}
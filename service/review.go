@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	model "github.com/harrydrippin/go-spreadsheet-library/model"
+	repositories "github.com/harrydrippin/go-spreadsheet-library/repository"
+)
+
+// Number of recent reviews included in a summary
+const recentReviewCount = 3
+
+// ReviewUsecase is the interface that defines the usecase for book ratings and reviews
+type ReviewUsecase interface {
+	Rate(ctx context.Context, bookId int, reviewer string, rating int, comment string) (model.Review, error)
+	Reviews(ctx context.Context, bookId int) ([]model.Review, error)
+	Summary(ctx context.Context, bookId int) (model.ReviewSummary, error)
+	Summaries(ctx context.Context, bookIds []int) (map[int]model.ReviewSummary, error)
+}
+
+// ReviewService is the service that handles the review usecase.
+// Ratings are saved one at a time, as the repository reads the rows to decide whether to update or append.
+type ReviewService struct {
+	repository     repositories.ReviewRepository
+	bookRepository repositories.BookRepository
+
+	mutex sync.Mutex
+}
+
+// NewReviewService returns a new instance of ReviewService
+func NewReviewService(repository repositories.ReviewRepository, bookRepository repositories.BookRepository) *ReviewService {
+	return &ReviewService{repository: repository, bookRepository: bookRepository}
+}
+
+func (s *ReviewService) Rate(ctx context.Context, bookId int, reviewer string, rating int, comment string) (model.Review, error) {
+	if rating < 1 || rating > 5 {
+		return model.Review{}, i18n.Wrap(model.ErrInvalidInput, "error.rating_range")
+	}
+
+	comment = strings.TrimSpace(comment)
+	if len([]rune(comment)) > 300 {
+		return model.Review{}, i18n.Wrap(model.ErrInvalidInput, "error.comment_too_long")
+	}
+
+	if _, err := s.bookRepository.SearchById(ctx, bookId); err != nil {
+		return model.Review{}, err
+	}
+
+	review := model.Review{
+		BookID:    bookId,
+		Reviewer:  reviewer,
+		Rating:    rating,
+		Comment:   comment,
+		CreatedAt: time.Now().Format("2006-01-02"),
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.repository.Save(ctx, review)
+
+	return review, err
+}
+
+// Reviews returns every review of the book, most recent first
+func (s *ReviewService) Reviews(ctx context.Context, bookId int) ([]model.Review, error) {
+	reviews, err := s.repository.SearchByBookId(ctx, bookId)
+	if err != nil {
+		return nil, err
+	}
+
+	sortRecentFirst(reviews)
+	return reviews, nil
+}
+
+func (s *ReviewService) Summary(ctx context.Context, bookId int) (model.ReviewSummary, error) {
+	reviews, err := s.repository.SearchByBookId(ctx, bookId)
+	if err != nil {
+		return model.ReviewSummary{}, err
+	}
+
+	return summarize(bookId, reviews), nil
+}
+
+// Summaries returns the review summaries of the given books, reading the reviews only once
+func (s *ReviewService) Summaries(ctx context.Context, bookIds []int) (map[int]model.ReviewSummary, error) {
+	reviews, err := s.repository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewsByBook := make(map[int][]model.Review)
+	for _, review := range reviews {
+		reviewsByBook[review.BookID] = append(reviewsByBook[review.BookID], review)
+	}
+
+	result := make(map[int]model.ReviewSummary)
+	for _, bookId := range bookIds {
+		result[bookId] = summarize(bookId, reviewsByBook[bookId])
+	}
+
+	return result, nil
+}
+
+func summarize(bookId int, reviews []model.Review) model.ReviewSummary {
+	summary := model.ReviewSummary{BookID: bookId, Count: len(reviews), Recent: []model.Review{}}
+	if len(reviews) == 0 {
+		return summary
+	}
+
+	total := 0
+	for _, review := range reviews {
+		total += review.Rating
+	}
+	summary.Average = float64(total) / float64(len(reviews))
+
+	sortRecentFirst(reviews)
+	for _, review := range reviews {
+		if len(summary.Recent) == recentReviewCount {
+			break
+		}
+		if review.Comment != "" {
+			summary.Recent = append(summary.Recent, review)
+		}
+	}
+
+	return summary
+}
+
+// sortRecentFirst sorts reviews by date, keeping later rows first for reviews on the same day
+func sortRecentFirst(reviews []model.Review) {
+	for i, j := 0, len(reviews)-1; i < j; i, j = i+1, j-1 {
+		reviews[i], reviews[j] = reviews[j], reviews[i]
+	}
+
+	sort.SliceStable(reviews, func(i, j int) bool {
+		return reviews[i].CreatedAt > reviews[j].CreatedAt
+	})
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	model "github.com/harrydrippin/go-spreadsheet-library/model"
+	repositories "github.com/harrydrippin/go-spreadsheet-library/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// RoleResolver resolves the role of a library user by the Slack user name
+type RoleResolver interface {
+	Role(user string) model.Role
+}
+
+// RoleService resolves the roles in the configuration, which are read once and cached.
+// Users without a role are members.
+type RoleService struct {
+	repository repositories.RoleRepository
+
+	mutex sync.Mutex
+	roles map[string]model.Role
+}
+
+// NewRoleService returns a new instance of RoleService
+func NewRoleService(repository repositories.RoleRepository) *RoleService {
+	return &RoleService{repository: repository}
+}
+
+func (s *RoleService) Role(user string) model.Role {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.roles == nil {
+		roles, err := s.repository.GetAll()
+		if err != nil {
+			// 설정을 읽지 못하면 모두 회원으로 취급하고, 다음 요청에서 다시 읽음
+			logrus.WithError(err).Error("Unable to load the roles")
+			return model.RoleMember
+		}
+		s.roles = roles
+	}
+
+	if role, ok := s.roles[user]; ok {
+		return role
+	}
+
+	return model.RoleMember
+}
+
+// requireRole fails with ErrForbidden unless the user has the role or a more privileged one
+func requireRole(roles RoleResolver, user string, role model.Role) error {
+	if !roles.Role(user).Includes(role) {
+		return i18n.Wrap(model.ErrForbidden, "error.role_required", role)
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	model "github.com/harrydrippin/go-spreadsheet-library/model"
+	repositories "github.com/harrydrippin/go-spreadsheet-library/repository"
+)
+
+// StocktakeUsecase is the interface that defines the usecase for physical audits of the shelves
+type StocktakeUsecase interface {
+	Open(ctx context.Context, admin string) (model.StocktakeSession, error)
+	Current(ctx context.Context) (model.StocktakeSession, error)
+	MarkSeen(ctx context.Context, bookId int, position, user string) (model.Book, error)
+	Close(ctx context.Context, admin string) (model.StocktakeReport, error)
+	Report(ctx context.Context) (model.StocktakeReport, error)
+}
+
+// StocktakeService is the service that handles the stocktake usecase.
+// Opening, marking and closing are serialized, so that two sessions are never open at once
+// and no sighting is added to a session while it is being closed.
+type StocktakeService struct {
+	repository     repositories.StocktakeRepository
+	bookRepository repositories.BookRepository
+	roles          RoleResolver
+
+	mutex sync.Mutex
+}
+
+// NewStocktakeService returns a new instance of StocktakeService
+func NewStocktakeService(repository repositories.StocktakeRepository, bookRepository repositories.BookRepository, roles RoleResolver) *StocktakeService {
+	return &StocktakeService{
+		repository:     repository,
+		bookRepository: bookRepository,
+		roles:          roles,
+	}
+}
+
+var errNoStocktake = i18n.Wrap(model.ErrConflict, "error.no_stocktake")
+
+func (s *StocktakeService) Open(ctx context.Context, admin string) (model.StocktakeSession, error) {
+	if err := requireRole(s.roles, admin, model.RoleLibrarian); err != nil {
+		return model.StocktakeSession{}, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if session, err := s.Current(ctx); err == nil {
+		return model.StocktakeSession{}, i18n.Wrap(model.ErrConflict, "error.stocktake_in_progress", session.ID)
+	} else if err != errNoStocktake {
+		return model.StocktakeSession{}, err
+	}
+
+	session := model.StocktakeSession{OpenedBy: admin, OpenedAt: time.Now().Format("2006-01-02 15:04")}
+	return s.repository.CreateSession(ctx, session)
+}
+
+// Current returns the open stocktake session
+func (s *StocktakeService) Current(ctx context.Context) (model.StocktakeSession, error) {
+	sessions, err := s.repository.GetSessions(ctx)
+	if err != nil {
+		return model.StocktakeSession{}, err
+	}
+
+	for i := len(sessions) - 1; i >= 0; i-- {
+		if sessions[i].IsOpen() {
+			return sessions[i], nil
+		}
+	}
+
+	return model.StocktakeSession{}, errNoStocktake
+}
+
+// MarkSeen records that the book was found on the shelf. An empty position means it was at its catalog position.
+func (s *StocktakeService) MarkSeen(ctx context.Context, bookId int, position, user string) (model.Book, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	session, err := s.Current(ctx)
+	if err != nil {
+		return model.Book{}, err
+	}
+
+	book, err := s.bookRepository.SearchById(ctx, bookId)
+	if err != nil {
+		return model.Book{}, i18n.Wrap(model.ErrNotFound, "error.book_not_found", bookId)
+	}
+
+	position = strings.TrimSpace(position)
+	if position == "" {
+		position = book.Position
+	}
+
+	sighting := model.Sighting{
+		SessionID: session.ID,
+		BookID:    book.ID,
+		Position:  position,
+		SeenBy:    user,
+		SeenAt:    time.Now().Format("2006-01-02 15:04"),
+	}
+	err = s.repository.CreateSighting(ctx, sighting)
+
+	return book, err
+}
+
+func (s *StocktakeService) Close(ctx context.Context, admin string) (model.StocktakeReport, error) {
+	if err := requireRole(s.roles, admin, model.RoleLibrarian); err != nil {
+		return model.StocktakeReport{}, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	session, err := s.Current(ctx)
+	if err != nil {
+		return model.StocktakeReport{}, err
+	}
+
+	session.ClosedAt = time.Now().Format("2006-01-02 15:04")
+	if err := s.repository.UpdateSession(ctx, session); err != nil {
+		return model.StocktakeReport{}, err
+	}
+
+	return s.report(ctx, session)
+}
+
+// Report returns the interim report of the open stocktake session
+func (s *StocktakeService) Report(ctx context.Context) (model.StocktakeReport, error) {
+	session, err := s.Current(ctx)
+	if err != nil {
+		return model.StocktakeReport{}, err
+	}
+
+	return s.report(ctx, session)
+}
+
+func (s *StocktakeService) report(ctx context.Context, session model.StocktakeSession) (model.StocktakeReport, error) {
+	books, err := s.bookRepository.GetAll(ctx)
+	if err != nil {
+		return model.StocktakeReport{}, err
+	}
+
+	sightings, err := s.repository.GetSightings(ctx, session.ID)
+	if err != nil {
+		return model.StocktakeReport{}, err
+	}
+
+	// 같은 책을 여러 번 확인한 경우 마지막 기록을 사용
+	seen := make(map[int]model.Sighting)
+	for _, sighting := range sightings {
+		seen[sighting.BookID] = sighting
+	}
+
+	report := model.StocktakeReport{
+		Session:         session,
+		Total:           len(books),
+		Seen:            len(seen),
+		Missing:         []model.Book{},
+		BorrowedOnShelf: []model.Book{},
+		Misplaced:       []model.MisplacedBook{},
+	}
+
+	for _, book := range books {
+		sighting, ok := seen[book.ID]
+		switch {
+		case !ok && book.Status == model.StatusInOffice:
+			report.Missing = append(report.Missing, book)
+		case ok && (book.Status == model.StatusBorrowed || book.Status == model.StatusOverdue):
+			report.BorrowedOnShelf = append(report.BorrowedOnShelf, book)
+		}
+
+		if ok && sighting.Position != book.Position {
+			report.Misplaced = append(report.Misplaced, model.MisplacedBook{Book: book, SeenPosition: sighting.Position})
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+
+	model "github.com/harrydrippin/go-spreadsheet-library/model"
+	"github.com/harrydrippin/go-spreadsheet-library/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TracedLibraryService wraps a LibraryUsecase in a span for each method, so that the time spent in the service
+// can be told apart from the time spent in the handler and in the Sheets API
+type TracedLibraryService struct {
+	library LibraryUsecase
+}
+
+// NewTracedLibraryService returns a new instance of TracedLibraryService
+func NewTracedLibraryService(library LibraryUsecase) *TracedLibraryService {
+	return &TracedLibraryService{library: library}
+}
+
+// startSpan starts the span of the method. The name of the user is left out, as traces are kept outside the library.
+func startSpan(ctx context.Context, method string, attributes ...attribute.KeyValue) (context.Context, func(error)) {
+	ctx, span := tracing.Start(ctx, "LibraryUsecase."+method, attributes...)
+	return ctx, func(err error) { tracing.End(span, err) }
+}
+
+func bookID(id int) attribute.KeyValue {
+	return attribute.Int("book.id", id)
+}
+
+func (t *TracedLibraryService) List(ctx context.Context) (books []model.Book, err error) {
+	ctx, end := startSpan(ctx, "List")
+	defer func() { end(err) }()
+
+	return t.library.List(ctx)
+}
+
+func (t *TracedLibraryService) Search(ctx context.Context, title string) (books []model.Book, err error) {
+	ctx, end := startSpan(ctx, "Search")
+	defer func() { end(err) }()
+
+	return t.library.Search(ctx, title)
+}
+
+func (t *TracedLibraryService) SearchById(ctx context.Context, id int) (book model.Book, err error) {
+	ctx, end := startSpan(ctx, "SearchById", bookID(id))
+	defer func() { end(err) }()
+
+	return t.library.SearchById(ctx, id)
+}
+
+func (t *TracedLibraryService) Borrow(ctx context.Context, book model.Book, borrower string) (result model.Book, err error) {
+	ctx, end := startSpan(ctx, "Borrow", bookID(book.ID))
+	defer func() { end(err) }()
+
+	return t.library.Borrow(ctx, book, borrower)
+}
+
+func (t *TracedLibraryService) Return(ctx context.Context, book model.Book, borrower string) (result model.Book, err error) {
+	ctx, end := startSpan(ctx, "Return", bookID(book.ID))
+	defer func() { end(err) }()
+
+	return t.library.Return(ctx, book, borrower)
+}
+
+func (t *TracedLibraryService) Extend(ctx context.Context, book model.Book, borrower string) (result model.Book, err error) {
+	ctx, end := startSpan(ctx, "Extend", bookID(book.ID))
+	defer func() { end(err) }()
+
+	return t.library.Extend(ctx, book, borrower)
+}
+
+func (t *TracedLibraryService) Status(ctx context.Context, borrower string) (books []model.Book, err error) {
+	ctx, end := startSpan(ctx, "Status")
+	defer func() { end(err) }()
+
+	return t.library.Status(ctx, borrower)
+}
+
+func (t *TracedLibraryService) SearchAll(ctx context.Context, title string) (books []model.Book, err error) {
+	ctx, end := startSpan(ctx, "SearchAll")
+	defer func() { end(err) }()
+
+	return t.library.SearchAll(ctx, title)
+}
+
+func (t *TracedLibraryService) ChangeStatus(ctx context.Context, book model.Book, status model.Status, admin string) (result model.Book, err error) {
+	ctx, end := startSpan(ctx, "ChangeStatus", bookID(book.ID), attribute.String("book.status", string(status)))
+	defer func() { end(err) }()
+
+	return t.library.ChangeStatus(ctx, book, status, admin)
+}
+
+func (t *TracedLibraryService) ForceReturn(ctx context.Context, book model.Book, librarian string) (result model.Book, err error) {
+	ctx, end := startSpan(ctx, "ForceReturn", bookID(book.ID))
+	defer func() { end(err) }()
+
+	return t.library.ForceReturn(ctx, book, librarian)
+}
+
+func (t *TracedLibraryService) ChangeDueDate(ctx context.Context, book model.Book, dueDate string, librarian string) (result model.Book, err error) {
+	ctx, end := startSpan(ctx, "ChangeDueDate", bookID(book.ID))
+	defer func() { end(err) }()
+
+	return t.library.ChangeDueDate(ctx, book, dueDate, librarian)
+}
+
+func (t *TracedLibraryService) EditDetails(ctx context.Context, book model.Book, details model.BookDetails, librarian string) (result model.Book, err error) {
+	ctx, end := startSpan(ctx, "EditDetails", bookID(book.ID))
+	defer func() { end(err) }()
+
+	return t.library.EditDetails(ctx, book, details, librarian)
+}
+
+func (t *TracedLibraryService) Delete(ctx context.Context, book model.Book, admin string) (err error) {
+	ctx, end := startSpan(ctx, "Delete", bookID(book.ID))
+	defer func() { end(err) }()
+
+	return t.library.Delete(ctx, book, admin)
+}
+
+func (t *TracedLibraryService) AddBook(ctx context.Context, details model.BookDetails, admin string) (book model.Book, err error) {
+	ctx, end := startSpan(ctx, "AddBook")
+	defer func() { end(err) }()
+
+	return t.library.AddBook(ctx, details, admin)
+}
+
+func (t *TracedLibraryService) Archive(ctx context.Context, book model.Book, admin string) (result model.Book, err error) {
+	ctx, end := startSpan(ctx, "Archive", bookID(book.ID))
+	defer func() { end(err) }()
+
+	return t.library.Archive(ctx, book, admin)
+}
+
+func (t *TracedLibraryService) Reassign(ctx context.Context, book model.Book, borrower string, librarian string) (result model.Book, err error) {
+	ctx, end := startSpan(ctx, "Reassign", bookID(book.ID))
+	defer func() { end(err) }()
+
+	return t.library.Reassign(ctx, book, borrower, librarian)
+}
+
+func (t *TracedLibraryService) AuditTrail(ctx context.Context, id int, librarian string) (entries []model.AuditEntry, err error) {
+	ctx, end := startSpan(ctx, "AuditTrail", bookID(id))
+	defer func() { end(err) }()
+
+	return t.library.AuditTrail(ctx, id, librarian)
+}
+
+func (t *TracedLibraryService) LostBooks(ctx context.Context, librarian string) (books map[string][]model.Book, err error) {
+	ctx, end := startSpan(ctx, "LostBooks")
+	defer func() { end(err) }()
+
+	return t.library.LostBooks(ctx, librarian)
+}
+
+func (t *TracedLibraryService) MarkOverdue(ctx context.Context) (books []model.Book, err error) {
+	ctx, end := startSpan(ctx, "MarkOverdue")
+	defer func() { end(err) }()
+
+	return t.library.MarkOverdue(ctx)
+}
+
+func (t *TracedLibraryService) Loans(ctx context.Context, borrower string) (loans []model.Loan, err error) {
+	ctx, end := startSpan(ctx, "Loans")
+	defer func() { end(err) }()
+
+	return t.library.Loans(ctx, borrower)
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	"github.com/harrydrippin/go-spreadsheet-library/logging"
+	model "github.com/harrydrippin/go-spreadsheet-library/model"
+	repositories "github.com/harrydrippin/go-spreadsheet-library/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// Number of attempts to deliver an event, waiting twice as long before each retry
+const (
+	webhookAttempts = 5
+	webhookBackoff  = 2 * time.Second
+)
+
+// WebhookUsecase is the interface that defines the usecase for outbound webhooks
+type WebhookUsecase interface {
+	Handle(ctx context.Context, event model.DomainEvent) error
+	Webhooks(ctx context.Context, admin string) ([]model.Webhook, error)
+	Deliveries(ctx context.Context, webhook string, librarian string) ([]model.WebhookDelivery, error)
+	Test(ctx context.Context, name string, admin string) (model.WebhookDelivery, error)
+}
+
+// WebhookService delivers loan events to the webhooks in the configuration, which are read once and cached.
+// Each payload is signed with the secret of the webhook, and every attempt is recorded in the delivery log.
+type WebhookService struct {
+	repository         repositories.WebhookRepository
+	deliveryRepository repositories.WebhookDeliveryRepository
+	roles              RoleResolver
+	client             *http.Client
+	backoff            time.Duration
+
+	mutex    sync.Mutex
+	webhooks []model.Webhook
+}
+
+// NewWebhookService returns a new instance of WebhookService
+func NewWebhookService(repository repositories.WebhookRepository, deliveryRepository repositories.WebhookDeliveryRepository, roles RoleResolver) *WebhookService {
+	return &WebhookService{
+		repository:         repository,
+		deliveryRepository: deliveryRepository,
+		roles:              roles,
+		client:             &http.Client{Timeout: 10 * time.Second},
+		backoff:            webhookBackoff,
+	}
+}
+
+// Handle delivers the domain event in the background to every webhook subscribed to it
+func (s *WebhookService) Handle(ctx context.Context, event model.DomainEvent) error {
+	webhooks, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	book := event.EventBook()
+	if returned, ok := event.(model.BookReturned); ok {
+		// 반납한 사람을 알 수 있도록 대출자를 남겨서 보냄
+		book.Borrower = returned.Borrower
+	}
+
+	payload := newEvent(event.EventName(), &book)
+	for _, webhook := range webhooks {
+		if webhook.Wants(payload.Type) {
+			go s.deliver(logging.Detach(ctx), webhook, payload, webhookAttempts)
+		}
+	}
+
+	return nil
+}
+
+// Webhooks returns the webhooks in the configuration, without their secrets
+func (s *WebhookService) Webhooks(ctx context.Context, admin string) ([]model.Webhook, error) {
+	if err := requireRole(s.roles, admin, model.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	return s.load()
+}
+
+// Deliveries returns the delivery log of the webhook, or of every webhook without a name, newest first
+func (s *WebhookService) Deliveries(ctx context.Context, webhook string, librarian string) ([]model.WebhookDelivery, error) {
+	if err := requireRole(s.roles, librarian, model.RoleLibrarian); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.deliveryRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []model.WebhookDelivery{}
+	for _, delivery := range deliveries {
+		if webhook == "" || delivery.Webhook == webhook {
+			result = append(result, delivery)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].At > result[j].At
+	})
+
+	return result, nil
+}
+
+// Test sends a ping to the webhook once and returns the result, so that an admin can check the endpoint and its secret
+func (s *WebhookService) Test(ctx context.Context, name string, admin string) (model.WebhookDelivery, error) {
+	if err := requireRole(s.roles, admin, model.RoleAdmin); err != nil {
+		return model.WebhookDelivery{}, err
+	}
+
+	webhooks, err := s.load()
+	if err != nil {
+		return model.WebhookDelivery{}, err
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.Name == name {
+			return s.deliver(ctx, webhook, newEvent(model.EventPing, nil), 1), nil
+		}
+	}
+
+	return model.WebhookDelivery{}, i18n.Wrap(model.ErrNotFound, "error.webhook_not_found", name)
+}
+
+func (s *WebhookService) load() ([]model.Webhook, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.webhooks == nil {
+		webhooks, err := s.repository.GetAll()
+		if err != nil {
+			return nil, err
+		}
+		s.webhooks = webhooks
+	}
+
+	return s.webhooks, nil
+}
+
+// deliver sends the event until the webhook accepts it or the attempts run out.
+// Requests rejected with a 4xx status other than 429 are not retried, as they would fail again.
+func (s *WebhookService) deliver(ctx context.Context, webhook model.Webhook, event model.Event, attempts int) model.WebhookDelivery {
+	body, _ := json.Marshal(event)
+
+	logger := logging.FromContext(ctx).WithFields(logrus.Fields{"webhook": webhook.Name, "event": event.Type, "event_id": event.ID})
+	backoff := s.backoff
+	for attempt := 1; ; attempt++ {
+		delivery := s.send(ctx, webhook, event, body)
+		delivery.Attempt = attempt
+		if err := s.deliveryRepository.Create(ctx, delivery); err != nil {
+			logger.WithError(err).Error("Unable to record the webhook delivery")
+		}
+
+		retryable := delivery.StatusCode == 0 || delivery.StatusCode == http.StatusTooManyRequests || delivery.StatusCode >= 500
+		if delivery.Succeeded() || !retryable || attempt >= attempts {
+			if !delivery.Succeeded() {
+				logger.WithFields(logrus.Fields{"attempt": attempt, "status": delivery.StatusCode, "error": delivery.Error}).Warn("Webhook delivery failed")
+			}
+			return delivery
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// send posts the event to the webhook. The signature is the HMAC-SHA256 of the timestamp, a dot and the body.
+func (s *WebhookService) send(ctx context.Context, webhook model.Webhook, event model.Event, body []byte) model.WebhookDelivery {
+	delivery := model.WebhookDelivery{
+		At:      time.Now().Format("2006-01-02 15:04:05"),
+		Webhook: webhook.Name,
+		EventID: event.ID,
+		Event:   event.Type,
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(webhook.Secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "go-spreadsheet-library")
+	request.Header.Set("X-Library-Event", event.Type)
+	request.Header.Set("X-Library-Delivery", event.ID)
+	request.Header.Set("X-Library-Timestamp", timestamp)
+	request.Header.Set("X-Library-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	defer response.Body.Close()
+
+	delivery.StatusCode = response.StatusCode
+	if !delivery.Succeeded() {
+		delivery.Error = response.Status
+	}
+
+	return delivery
+}
+
+func newEvent(event string, book *model.Book) model.Event {
+	id := make([]byte, 16)
+	rand.Read(id)
+
+	return model.Event{
+		ID:   hex.EncodeToString(id),
+		Type: event,
+		At:   time.Now().Format(time.RFC3339),
+		Book: book,
+	}
+}
@@ -0,0 +1,85 @@
+// Package tracing exports OpenTelemetry spans of the HTTP requests, the use cases and the Sheets API calls.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName is the name the spans of the library are exported under
+const ServiceName = "go-spreadsheet-library"
+
+// Stdout is the endpoint that prints the spans to the standard output instead of sending them
+const Stdout = "stdout"
+
+// Setup exports the spans to the endpoint, which is the URL of an OTLP/HTTP collector such as http://localhost:4318,
+// or Stdout. Without an endpoint, nothing is exported and the spans cost next to nothing.
+// The returned function sends the spans left in the buffer, and should be called before the server exits.
+func Setup(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	// 다른 서비스에서 넘어온 요청도 같은 트레이스로 이어지도록 W3C Trace Context 헤더를 읽음
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, endpoint string) (sdktrace.SpanExporter, error) {
+	if endpoint == Stdout {
+		return stdouttrace.New()
+	}
+
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid tracing endpoint %q: expected a URL such as http://localhost:4318 or %s", endpoint, Stdout)
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(parsed.Host)}
+	if parsed.Scheme == "http" {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	if parsed.Path != "" && parsed.Path != "/" {
+		options = append(options, otlptracehttp.WithURLPath(parsed.Path))
+	}
+
+	return otlptracehttp.New(ctx, options...)
+}
+
+// Start starts a span named after the operation, as a child of the span in the context if there is one
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(ServiceName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End marks the span as failed with the error, if any, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -6,15 +6,14 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
-	"net/http"
 	"os"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 )
 
-// GetGoogleClient is a function for obtaining oAuth 2.0 Client from Google
-func GetGoogleClient(credentialPath string) *http.Client {
+// GetGoogleTokenSource is a function for obtaining the oAuth 2.0 tokens from Google, refreshed when they expire
+func GetGoogleTokenSource(credentialPath string) oauth2.TokenSource {
 	rawClientSecret, err := ioutil.ReadFile(credentialPath)
 	if err != nil {
 		log.Fatal("Error reading client secret file", err)
@@ -38,7 +37,7 @@ func GetGoogleClient(credentialPath string) *http.Client {
 		defer tokenFile.Close()
 		json.NewEncoder(tokenFile).Encode(token)
 	}
-	return oAuthConfig.Client(context.Background(), token)
+	return oAuthConfig.TokenSource(context.Background(), token)
 }
 
 func getTokenFromFile(tokenFileName string) (*oauth2.Token, error) {
@@ -2,19 +2,47 @@ package utils
 
 import (
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 // Config is a struct to hold all the configuration values from dotenv
 type Config struct {
-	GoogleOAuthClientID     string
-	GoogleOAuthClientSecret string
-	GoogleSpreadsheetID     string
-	GoogleSpreadsheetName   string
-	GoogleCredentialJSON    string
-	SlackToken              string
-	SlackSigningSecret      string
+	GoogleOAuthClientID            string
+	GoogleOAuthClientSecret        string
+	GoogleSpreadsheetID            string
+	GoogleSpreadsheetName          string
+	GoogleCredentialJSON           string
+	GooglePurchaseRequestSheetName string
+	GoogleReviewSheetName          string
+	GoogleLoanSheetName            string
+	GoogleStocktakeSheetName       string
+	GoogleSightingSheetName        string
+	GooglePreferenceSheetName      string
+	GoogleAuditSheetName           string
+	GoogleWebhookSheetName         string
+	SlackToken                     string
+	SlackSigningSecret             string
+	ServerBaseURL                  string
+	PDFFontPath                    string
+	LibraryAdmins                  []string
+	LibraryLibrarians              []string
+	RolesFile                      string
+	SlackAdminGroup                string
+	SlackLibrarianGroup            string
+	ValidateAPIResponses           bool
+	APIJWTSecret                   string
+	APITokenTTL                    time.Duration
+	GraphQLMaxComplexity           int
+	GraphQLMaxDepth                int
+	GRPCAddress                    string
+	WebhooksFile                   string
+	LogLevel                       string
+	TracingEndpoint                string
+	HealthCheckTTL                 time.Duration
 }
 
 // NewConfig creates a new Config object
@@ -23,12 +51,77 @@ func NewConfig() *Config {
 	godotenv.Load()
 
 	return &Config{
-		GoogleOAuthClientID:     os.Getenv("GOOGLE_OAUTH_CLIENT_ID"),
-		GoogleOAuthClientSecret: os.Getenv("GOOGLE_OAUTH_CLIENT_SECRET"),
-		GoogleSpreadsheetID:     os.Getenv("GOOGLE_SPREADSHEET_ID"),
-		GoogleSpreadsheetName:   os.Getenv("GOOGLE_SPREADSHEET_NAME"),
-		GoogleCredentialJSON:    os.Getenv("GOOGLE_CREDENTIAL_JSON"),
-		SlackToken:              os.Getenv("SLACK_TOKEN"),
-		SlackSigningSecret:      os.Getenv("SLACK_SIGNING_SECRET"),
+		GoogleOAuthClientID:            os.Getenv("GOOGLE_OAUTH_CLIENT_ID"),
+		GoogleOAuthClientSecret:        os.Getenv("GOOGLE_OAUTH_CLIENT_SECRET"),
+		GoogleSpreadsheetID:            os.Getenv("GOOGLE_SPREADSHEET_ID"),
+		GoogleSpreadsheetName:          os.Getenv("GOOGLE_SPREADSHEET_NAME"),
+		GoogleCredentialJSON:           os.Getenv("GOOGLE_CREDENTIAL_JSON"),
+		GooglePurchaseRequestSheetName: getEnvOrDefault("GOOGLE_PURCHASE_REQUEST_SHEET_NAME", "구매 신청"),
+		GoogleReviewSheetName:          getEnvOrDefault("GOOGLE_REVIEW_SHEET_NAME", "리뷰"),
+		GoogleLoanSheetName:            getEnvOrDefault("GOOGLE_LOAN_SHEET_NAME", "대출 기록"),
+		GoogleStocktakeSheetName:       getEnvOrDefault("GOOGLE_STOCKTAKE_SHEET_NAME", "재고 조사"),
+		GoogleSightingSheetName:        getEnvOrDefault("GOOGLE_SIGHTING_SHEET_NAME", "재고 조사 기록"),
+		GooglePreferenceSheetName:      getEnvOrDefault("GOOGLE_PREFERENCE_SHEET_NAME", "사용자 설정"),
+		GoogleAuditSheetName:           getEnvOrDefault("GOOGLE_AUDIT_SHEET_NAME", "변경 기록"),
+		GoogleWebhookSheetName:         getEnvOrDefault("GOOGLE_WEBHOOK_SHEET_NAME", "웹훅 전송 기록"),
+		SlackToken:                     os.Getenv("SLACK_TOKEN"),
+		SlackSigningSecret:             os.Getenv("SLACK_SIGNING_SECRET"),
+		ServerBaseURL:                  os.Getenv("SERVER_BASE_URL"),
+		PDFFontPath:                    os.Getenv("PDF_FONT_PATH"),
+		LibraryAdmins:                  splitList(os.Getenv("LIBRARY_ADMINS")),
+		LibraryLibrarians:              splitList(os.Getenv("LIBRARY_LIBRARIANS")),
+		RolesFile:                      os.Getenv("ROLES_FILE"),
+		SlackAdminGroup:                os.Getenv("SLACK_ADMIN_GROUP"),
+		SlackLibrarianGroup:            os.Getenv("SLACK_LIBRARIAN_GROUP"),
+		ValidateAPIResponses:           os.Getenv("OPENAPI_VALIDATE_RESPONSES") == "true",
+		APIJWTSecret:                   os.Getenv("API_JWT_SECRET"),
+		APITokenTTL:                    getDurationOrDefault("API_TOKEN_TTL", 90*24*time.Hour),
+		GraphQLMaxComplexity:           getIntOrDefault("GRAPHQL_MAX_COMPLEXITY", 1000),
+		GraphQLMaxDepth:                getIntOrDefault("GRAPHQL_MAX_DEPTH", 6),
+		GRPCAddress:                    getEnvOrDefault("GRPC_ADDRESS", ":9090"),
+		WebhooksFile:                   os.Getenv("WEBHOOKS_FILE"),
+		LogLevel:                       getEnvOrDefault("LOG_LEVEL", "info"),
+		TracingEndpoint:                os.Getenv("TRACING_ENDPOINT"),
+		HealthCheckTTL:                 getDurationOrDefault("HEALTH_CHECK_TTL", 30*time.Second),
 	}
 }
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+
+	return defaultValue
+}
+
+// getDurationOrDefault parses a duration such as "720h", falling back to the default if it is missing or invalid
+func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	duration, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || duration <= 0 {
+		return defaultValue
+	}
+
+	return duration
+}
+
+// getIntOrDefault parses a positive number, falling back to the default if it is missing or invalid
+func getIntOrDefault(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+
+	return value
+}
+
+// splitList splits a comma-separated value into a list, ignoring empty items
+func splitList(value string) []string {
+	result := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
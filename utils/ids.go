@@ -5,6 +5,18 @@ const (
 	BorrowThisBook = "borrow_this_book"
 	ReturnThisBook = "return_this_book"
 	ExtendThisBook = "extend_this_book"
+
+	RequestThisBook = "request_this_book"
+	VoteThisRequest = "vote_this_request"
+
+	RateThisBook = "rate_this_book"
+)
+
+// Modal callback and input
+const (
+	RateBookModal = "rate_book_modal"
+	RatingInput   = "rating_input"
+	CommentInput  = "comment_input"
 )
 
 // Shortcut action
@@ -0,0 +1,91 @@
+package view
+
+import (
+	"embed"
+	"io"
+
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+)
+
+//go:embed static
+var StaticFS embed.FS
+
+// AdminPage is what every page of the admin console shows: the signed-in librarian and the result of the last action.
+type AdminPage struct {
+	Locale   string
+	Identity models.Identity
+	CSRF     string
+	Message  string
+	Error    string
+}
+
+// AdminLoginPage is the sign-in page of the admin console.
+type AdminLoginPage struct {
+	Locale string
+	Error  string
+}
+
+// AdminCatalogPage lists the books in the catalog, optionally filtered by title.
+type AdminCatalogPage struct {
+	AdminPage
+	Query string
+	All   bool
+	Books []models.Book
+}
+
+// AdminBookPage is the form for adding a book, or for editing a book along with its loan and audit history.
+type AdminBookPage struct {
+	AdminPage
+	Book     models.Book
+	Statuses []models.Status
+	Entries  []models.AuditEntry
+}
+
+// AdminLoansPage lists the books borrowed now, or only the overdue ones.
+type AdminLoansPage struct {
+	AdminPage
+	Overdue bool
+	Today   string
+	Books   []models.Book
+}
+
+// AdminAuditPage lists every change made by librarians and admins, newest first.
+type AdminAuditPage struct {
+	AdminPage
+	Entries []models.AuditEntry
+}
+
+// IsNew reports whether the page is for adding a book.
+func (p AdminBookPage) IsNew() bool {
+	return p.Book.ID == 0
+}
+
+// IsBorrowed reports whether the book on the page is borrowed now.
+func (p AdminBookPage) IsBorrowed() bool {
+	return p.Book.Status == models.StatusBorrowed || p.Book.Status == models.StatusOverdue
+}
+
+// RenderAdminLoginPage writes the sign-in page of the admin console.
+func RenderAdminLoginPage(w io.Writer, page AdminLoginPage) error {
+	return templates.ExecuteTemplate(w, "admin_login.html", page)
+}
+
+// RenderAdminCatalogPage writes the catalog page of the admin console.
+func RenderAdminCatalogPage(w io.Writer, page AdminCatalogPage) error {
+	return templates.ExecuteTemplate(w, "admin_catalog.html", page)
+}
+
+// RenderAdminBookPage writes the page for adding or editing a book.
+func RenderAdminBookPage(w io.Writer, page AdminBookPage) error {
+	return templates.ExecuteTemplate(w, "admin_book.html", page)
+}
+
+// RenderAdminLoansPage writes the page of current or overdue loans.
+func RenderAdminLoansPage(w io.Writer, page AdminLoansPage) error {
+	return templates.ExecuteTemplate(w, "admin_loans.html", page)
+}
+
+// RenderAdminAuditPage writes the audit history page.
+func RenderAdminAuditPage(w io.Writer, page AdminAuditPage) error {
+	return templates.ExecuteTemplate(w, "admin_audit.html", page)
+}
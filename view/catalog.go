@@ -0,0 +1,59 @@
+package view
+
+import (
+	"io"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+)
+
+// CatalogPage is the public catalog of the books in circulation, filtered by title, availability and position.
+type CatalogPage struct {
+	Locale    string
+	Query     string
+	Status    string
+	Position  string
+	Positions []string
+	Books     []models.Book
+	Summaries map[int]models.ReviewSummary
+}
+
+// CatalogBookPage is the public page of a single book with its ratings.
+type CatalogBookPage struct {
+	Locale  string
+	Book    models.Book
+	Summary models.ReviewSummary
+}
+
+// Availability tells whether the book can be borrowed now, or when it is due back.
+func (p CatalogPage) Availability(book models.Book) string {
+	return availability(p.Locale, book)
+}
+
+// Availability tells whether the book on the page can be borrowed now, or when it is due back.
+func (p CatalogBookPage) Availability() string {
+	return availability(p.Locale, p.Book)
+}
+
+// availability does not name the borrower, as the catalog is open to anyone
+func availability(locale string, book models.Book) string {
+	if book.IsAvailable() {
+		return i18n.T(locale, "catalog.available")
+	}
+
+	if book.DueDate == "" {
+		return i18n.T(locale, "catalog.borrowed")
+	}
+
+	return i18n.T(locale, "catalog.borrowed_until", book.DueDate)
+}
+
+// RenderCatalogPage writes the public, mobile-friendly catalog page.
+func RenderCatalogPage(w io.Writer, page CatalogPage) error {
+	return templates.ExecuteTemplate(w, "catalog.html", page)
+}
+
+// RenderCatalogBookPage writes the public page of a book.
+func RenderCatalogBookPage(w io.Writer, page CatalogBookPage) error {
+	return templates.ExecuteTemplate(w, "catalog_book.html", page)
+}
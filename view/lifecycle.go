@@ -0,0 +1,46 @@
+package view
+
+import (
+	"sort"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	"github.com/slack-go/slack"
+)
+
+func RenderLostBooks(lost map[string][]models.Book, locale string) slack.Message {
+	divSection := slack.NewDividerBlock()
+	sections := make([]slack.Block, 0)
+
+	total := 0
+	borrowers := []string{}
+	for borrower, books := range lost {
+		borrowers = append(borrowers, borrower)
+		total += len(books)
+	}
+	sort.Strings(borrowers)
+
+	// Header Text
+	headerText := i18n.T(locale, "lost.header", total)
+	headerTextBlock := slack.NewTextBlockObject("mrkdwn", headerText, false, false)
+	headerSection := slack.NewSectionBlock(headerTextBlock, nil, nil)
+	sections = append(sections, headerSection)
+
+	if total == 0 {
+		return slack.NewBlockMessage(sections...)
+	}
+	sections = append(sections, divSection)
+
+	for _, borrower := range borrowers {
+		title := i18n.T(locale, "lost.borrower", borrower, len(lost[borrower]))
+		if borrower == "" {
+			title = i18n.T(locale, "lost.shelf", len(lost[borrower]))
+		}
+
+		bookListText := title + "\n" + renderBookList(lost[borrower], locale)
+		bookListBlock := slack.NewTextBlockObject("mrkdwn", bookListText, false, false)
+		sections = append(sections, slack.NewSectionBlock(bookListBlock, nil, nil))
+	}
+
+	return slack.NewBlockMessage(sections...)
+}
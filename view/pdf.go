@@ -0,0 +1,231 @@
+package view
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+	"unicode"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Label layout on an A4 sheet (3 x 7, 63.5mm x 38.1mm labels)
+const (
+	labelColumns = 3
+	labelRows    = 7
+	labelWidth   = 63.5
+	labelHeight  = 38.1
+	labelMarginX = 7.2
+	labelMarginY = 15.1
+	labelGapX    = 2.5
+	labelQRSize  = 30.0
+)
+
+const pdfFontFamily = "library"
+
+// ErrFontRequired is returned when the PDF has text that the built-in fonts can't render, such as Hangul,
+// and no UTF-8 font is configured.
+var ErrFontRequired = errors.New("PDF_FONT_PATH must point to a UTF-8 font (e.g. NanumGothic.ttf) to render non-Latin text")
+
+// PDFLabel is a single label on the printable label PDF.
+type PDFLabel struct {
+	Book   models.Book
+	QRCode []byte
+}
+
+// newPDF creates an A4 document using the font at fontPath.
+// Without a font, it falls back to Helvetica only if every text to write is ASCII,
+// as the built-in fonts would render anything else, such as Hangul, as garbage.
+func newPDF(fontPath string, texts ...string) (*gofpdf.Fpdf, string, error) {
+	if fontPath == "" {
+		for _, text := range texts {
+			if !isASCII(text) {
+				return nil, "", ErrFontRequired
+			}
+		}
+		return gofpdf.New("P", "mm", "A4", ""), "Helvetica", nil
+	}
+
+	// gofpdf는 글꼴 파일 이름을 글꼴 디렉터리에 이어 붙이므로, 절대 경로도 디렉터리와 파일 이름으로 나눔
+	pdf := gofpdf.New("P", "mm", "A4", filepath.Dir(fontPath))
+	pdf.AddUTF8Font(pdfFontFamily, "", filepath.Base(fontPath))
+	pdf.AddUTF8Font(pdfFontFamily, "B", filepath.Base(fontPath))
+	if err := pdf.Error(); err != nil {
+		return nil, "", fmt.Errorf("unable to load the font %s: %w", fontPath, err)
+	}
+
+	return pdf, pdfFontFamily, nil
+}
+
+func isASCII(text string) bool {
+	for _, r := range text {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+
+	return true
+}
+
+// truncate shortens the text with an ellipsis so that it fits into the given width.
+func truncate(pdf *gofpdf.Fpdf, font string, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+
+	// 기본 글꼴로는 "…"를 쓸 수 없음
+	ellipsis := "…"
+	if font != pdfFontFamily {
+		ellipsis = "..."
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+ellipsis) > width {
+		runes = runes[:len(runes)-1]
+	}
+
+	return string(runes) + ellipsis
+}
+
+// RenderLabelPDF writes a printable A4 PDF of spine/shelf labels.
+func RenderLabelPDF(w io.Writer, labels []PDFLabel, fontPath string, locale string) error {
+	texts := []string{}
+	for _, label := range labels {
+		texts = append(texts, label.Book.Title, label.Book.Position)
+	}
+	pdf, font, err := newPDF(fontPath, texts...)
+	if err != nil {
+		return err
+	}
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetTitle(i18n.T(locale, "labels.title"), true)
+
+	textWidth := labelWidth - labelQRSize - 6
+	for i, label := range labels {
+		if i%(labelColumns*labelRows) == 0 {
+			pdf.AddPage()
+		}
+
+		cell := i % (labelColumns * labelRows)
+		x := labelMarginX + float64(cell%labelColumns)*(labelWidth+labelGapX)
+		y := labelMarginY + float64(cell/labelColumns)*labelHeight
+
+		imageName := "qr-" + strconv.Itoa(label.Book.ID)
+		options := gofpdf.ImageOptions{ImageType: "PNG"}
+		pdf.RegisterImageOptionsReader(imageName, options, bytes.NewReader(label.QRCode))
+		pdf.ImageOptions(imageName, x+2, y+(labelHeight-labelQRSize)/2, labelQRSize, labelQRSize, false, options, 0, "")
+
+		textX := x + labelQRSize + 4
+		pdf.SetFont(font, "B", 14)
+		pdf.SetXY(textX, y+5)
+		pdf.CellFormat(textWidth, 7, "#"+strconv.Itoa(label.Book.ID), "", 2, "L", false, 0, "")
+
+		pdf.SetFont(font, "", 8)
+		lines := pdf.SplitText(label.Book.Title, textWidth)
+		if len(lines) > 3 {
+			lines = append(lines[:2], truncate(pdf, font, lines[2]+lines[3], textWidth))
+		}
+		for _, line := range lines {
+			pdf.SetX(textX)
+			pdf.CellFormat(textWidth, 4, line, "", 2, "L", false, 0, "")
+		}
+
+		pdf.SetFont(font, "B", 10)
+		pdf.SetXY(textX, y+labelHeight-10)
+		pdf.CellFormat(textWidth, 5, truncate(pdf, font, label.Book.Position, textWidth), "", 0, "L", false, 0, "")
+	}
+
+	if len(labels) == 0 {
+		pdf.AddPage()
+	}
+
+	return pdf.Output(w)
+}
+
+// RenderCatalogPDF writes the full catalog as a PDF, grouped and sorted by position.
+func RenderCatalogPDF(w io.Writer, books []models.Book, fontPath string, locale string) error {
+	groups := make(map[string][]models.Book)
+	positions := []string{}
+	for _, book := range books {
+		if _, ok := groups[book.Position]; !ok {
+			positions = append(positions, book.Position)
+		}
+		groups[book.Position] = append(groups[book.Position], book)
+	}
+	sort.Strings(positions)
+
+	columns := []struct {
+		title string
+		width float64
+	}{
+		{"ID", 12}, {i18n.T(locale, "catalog.column_title"), 78}, {i18n.T(locale, "catalog.column_author"), 38},
+		{i18n.T(locale, "catalog.column_publisher"), 30}, {i18n.T(locale, "catalog.column_status"), 22},
+	}
+
+	texts := []string{
+		i18n.T(locale, "catalog.title"), i18n.T(locale, "catalog.summary", 0, ""),
+		i18n.T(locale, "catalog.position", "", 0), i18n.T(locale, "catalog.no_position"),
+	}
+	for _, column := range columns {
+		texts = append(texts, column.title)
+	}
+	for _, book := range books {
+		texts = append(texts, book.Title, book.Author, book.Publisher, book.Position, book.Status.Label(locale))
+	}
+	pdf, font, err := newPDF(fontPath, texts...)
+	if err != nil {
+		return err
+	}
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.SetTitle(i18n.T(locale, "catalog.title"), true)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont(font, "", 8)
+		pdf.CellFormat(0, 5, fmt.Sprintf("%d / {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	pdf.SetFont(font, "B", 16)
+	pdf.CellFormat(0, 10, i18n.T(locale, "catalog.title"), "", 1, "L", false, 0, "")
+	pdf.SetFont(font, "", 9)
+	pdf.CellFormat(0, 6, i18n.T(locale, "catalog.summary", len(books), time.Now().Format("2006-01-02")), "", 1, "L", false, 0, "")
+
+	for _, position := range positions {
+		title := position
+		if title == "" {
+			title = i18n.T(locale, "catalog.no_position")
+		}
+
+		pdf.Ln(4)
+		pdf.SetFont(font, "B", 12)
+		pdf.CellFormat(0, 8, i18n.T(locale, "catalog.position", title, len(groups[position])), "B", 1, "L", false, 0, "")
+
+		pdf.SetFont(font, "B", 9)
+		pdf.SetFillColor(240, 240, 240)
+		for _, column := range columns {
+			pdf.CellFormat(column.width, 6, column.title, "", 0, "L", true, 0, "")
+		}
+		pdf.Ln(-1)
+
+		pdf.SetFont(font, "", 9)
+		for _, book := range groups[position] {
+			values := []string{strconv.Itoa(book.ID), book.Title, book.Author, book.Publisher, book.Status.Label(locale)}
+			for i, column := range columns {
+				pdf.CellFormat(column.width, 6, truncate(pdf, font, values[i], column.width-1), "", 0, "L", false, 0, "")
+			}
+			pdf.Ln(-1)
+		}
+	}
+
+	return pdf.Output(w)
+}
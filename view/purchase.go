@@ -0,0 +1,87 @@
+package view
+
+import (
+	"strconv"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	"github.com/harrydrippin/go-spreadsheet-library/utils"
+	"github.com/slack-go/slack"
+)
+
+func renderPurchaseRequestInfo(request models.PurchaseRequest, locale string) string {
+	author := request.Author
+	if author == "" {
+		author = i18n.T(locale, "request.unknown_author")
+	}
+
+	return i18n.T(locale, "request.info", request.ID, request.Title, author, request.Requester, request.CreatedAt, request.Votes(), request.Status)
+}
+
+func RenderPurchaseRequestResult(request models.PurchaseRequest, locale string) slack.Message {
+	divSection := slack.NewDividerBlock()
+	sections := make([]slack.Block, 0)
+
+	// Header Text
+	headerText := i18n.T(locale, "request.done")
+	headerTextBlock := slack.NewTextBlockObject("mrkdwn", headerText, false, false)
+	headerSection := slack.NewSectionBlock(headerTextBlock, nil, nil)
+	sections = append(sections, headerSection, divSection)
+
+	// Request Info
+	requestInfoBlock := slack.NewTextBlockObject("mrkdwn", renderPurchaseRequestInfo(request, locale), false, false)
+	requestInfoSection := slack.NewSectionBlock(requestInfoBlock, nil, nil)
+	sections = append(sections, requestInfoSection)
+
+	return slack.NewBlockMessage(sections...)
+}
+
+func RenderPurchaseRequestList(requests []models.PurchaseRequest, user string, locale string) slack.Message {
+	divSection := slack.NewDividerBlock()
+	sections := make([]slack.Block, 0)
+
+	// Header Text
+	headerText := i18n.T(locale, "request.list_header", len(requests))
+	headerTextBlock := slack.NewTextBlockObject("mrkdwn", headerText, false, false)
+	headerSection := slack.NewSectionBlock(headerTextBlock, nil, nil)
+	sections = append(sections, headerSection)
+
+	if len(requests) == 0 {
+		additionalText := i18n.T(locale, "request.list_empty")
+		additionalTextBlock := slack.NewTextBlockObject("mrkdwn", additionalText, false, false)
+		additionalSection := slack.NewSectionBlock(additionalTextBlock, nil, nil)
+
+		sections = append(sections, additionalSection)
+		return slack.NewBlockMessage(sections...)
+	}
+
+	if len(requests) > 10 {
+		additionalText := i18n.T(locale, "request.list_too_many")
+		additionalTextBlock := slack.NewTextBlockObject("mrkdwn", additionalText, false, false)
+		additionalSection := slack.NewSectionBlock(additionalTextBlock, nil, nil)
+		sections = append(sections, additionalSection)
+		requests = requests[:10]
+	}
+	sections = append(sections, divSection)
+
+	for _, request := range requests {
+		requestInfoBlock := slack.NewTextBlockObject("mrkdwn", renderPurchaseRequestInfo(request, locale), false, false)
+
+		// 이미 추천했거나 처리된 신청에는 추천 버튼을 표시하지 않음
+		var accessory *slack.Accessory
+		if request.Status == models.RequestStatusPending && !request.HasVoted(user) {
+			accessory = slack.NewAccessory(
+				slack.NewButtonBlockElement(
+					utils.VoteThisRequest,
+					strconv.Itoa(request.ID),
+					slack.NewTextBlockObject("plain_text", i18n.T(locale, "button.vote"), false, false),
+				),
+			)
+		}
+
+		requestInfoSection := slack.NewSectionBlock(requestInfoBlock, nil, accessory)
+		sections = append(sections, requestInfoSection)
+	}
+
+	return slack.NewBlockMessage(sections...)
+}
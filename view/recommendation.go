@@ -0,0 +1,58 @@
+package view
+
+import (
+	"strconv"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	"github.com/harrydrippin/go-spreadsheet-library/utils"
+	"github.com/slack-go/slack"
+)
+
+func RenderRecommendationResult(recommendations []models.Recommendation, user string, locale string) slack.Message {
+	divSection := slack.NewDividerBlock()
+	sections := make([]slack.Block, 0)
+
+	// Header Text
+	headerText := i18n.T(locale, "recommendation.header", user)
+	if len(recommendations) == 0 {
+		headerText = i18n.T(locale, "recommendation.empty", user)
+	}
+	headerTextBlock := slack.NewTextBlockObject("mrkdwn", headerText, false, false)
+	headerSection := slack.NewSectionBlock(headerTextBlock, nil, nil)
+	sections = append(sections, headerSection)
+
+	if len(recommendations) == 0 {
+		return slack.NewBlockMessage(sections...)
+	}
+	sections = append(sections, divSection)
+
+	for _, recommendation := range recommendations {
+		book := recommendation.Book
+		statusText := book.Status.Label(locale)
+		if book.Status != models.StatusInOffice {
+			statusText = i18n.T(locale, "book.status_due", book.Status, book.Borrower, book.DueDate)
+		}
+
+		bookInfoText := i18n.T(locale, "book.info", book.Title, book.Author, book.Publisher, statusText)
+		bookInfoText += "\n>_" + i18n.T(locale, "recommendation.reason."+recommendation.Reason, recommendation.ReasonDetail) + "_"
+		bookInfoBlock := slack.NewTextBlockObject("mrkdwn", bookInfoText, false, false)
+
+		// 대출할 수 있는 책에만 대출 버튼을 표시
+		var accessory *slack.Accessory
+		if book.Status == models.StatusInOffice {
+			accessory = slack.NewAccessory(
+				slack.NewButtonBlockElement(
+					utils.BorrowThisBook,
+					strconv.Itoa(book.ID),
+					slack.NewTextBlockObject("plain_text", i18n.T(locale, "button.borrow"), false, false),
+				),
+			)
+		}
+
+		bookInfoSection := slack.NewSectionBlock(bookInfoBlock, nil, accessory)
+		sections = append(sections, bookInfoSection)
+	}
+
+	return slack.NewBlockMessage(sections...)
+}
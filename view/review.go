@@ -0,0 +1,46 @@
+package view
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	"github.com/harrydrippin/go-spreadsheet-library/utils"
+	"github.com/slack-go/slack"
+)
+
+func RenderRateModal(book models.Book, locale string) slack.ModalViewRequest {
+	bookInfoText := i18n.T(locale, "rate.book_info", book.Title, book.Author, book.Publisher)
+	bookInfoSection := slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", bookInfoText, false, false), nil, nil)
+
+	options := make([]*slack.OptionBlockObject, 0, 5)
+	for rating := 5; rating >= 1; rating-- {
+		text := strings.Repeat("★", rating) + strings.Repeat("☆", 5-rating)
+		options = append(options, slack.NewOptionBlockObject(strconv.Itoa(rating), slack.NewTextBlockObject("plain_text", text, false, false), nil))
+	}
+
+	ratingSelect := slack.NewOptionsSelectBlockElement(
+		slack.OptTypeStatic,
+		slack.NewTextBlockObject("plain_text", i18n.T(locale, "rate.rating_placeholder"), false, false),
+		utils.RatingInput,
+		options...,
+	)
+	ratingBlock := slack.NewInputBlock(utils.RatingInput, slack.NewTextBlockObject("plain_text", i18n.T(locale, "rate.rating"), false, false), ratingSelect)
+
+	commentInput := slack.NewPlainTextInputBlockElement(slack.NewTextBlockObject("plain_text", i18n.T(locale, "rate.comment_placeholder"), false, false), utils.CommentInput)
+	commentInput.Multiline = true
+	commentInput.MaxLength = 300
+	commentBlock := slack.NewInputBlock(utils.CommentInput, slack.NewTextBlockObject("plain_text", i18n.T(locale, "rate.comment"), false, false), commentInput)
+	commentBlock.Optional = true
+
+	return slack.ModalViewRequest{
+		Type:            slack.VTModal,
+		CallbackID:      utils.RateBookModal,
+		PrivateMetadata: strconv.Itoa(book.ID),
+		Title:           slack.NewTextBlockObject("plain_text", i18n.T(locale, "rate.title"), false, false),
+		Submit:          slack.NewTextBlockObject("plain_text", i18n.T(locale, "rate.submit"), false, false),
+		Close:           slack.NewTextBlockObject("plain_text", i18n.T(locale, "rate.cancel"), false, false),
+		Blocks:          slack.Blocks{BlockSet: []slack.Block{bookInfoSection, ratingBlock, commentBlock}},
+	}
+}
@@ -0,0 +1,68 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+	"github.com/slack-go/slack"
+)
+
+// Number of books listed per category in the stocktake report
+const stocktakeListLimit = 20
+
+func renderBookList(books []models.Book, locale string) string {
+	lines := []string{}
+	for i, book := range books {
+		if i == stocktakeListLimit {
+			lines = append(lines, i18n.T(locale, "list.more", len(books)-stocktakeListLimit))
+			break
+		}
+		lines = append(lines, fmt.Sprintf(">#%d *%s* (%s, %s)", book.ID, book.Title, book.Position, book.Status.Label(locale)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func RenderStocktakeReport(report models.StocktakeReport, locale string) slack.Message {
+	divSection := slack.NewDividerBlock()
+	sections := make([]slack.Block, 0)
+
+	// Header Text
+	headerText := i18n.T(locale, "stocktake.report_interim", report.Session.ID, report.Session.OpenedAt)
+	if !report.Session.IsOpen() {
+		headerText = i18n.T(locale, "stocktake.report_closed", report.Session.ID, report.Session.OpenedAt, report.Session.ClosedAt)
+	}
+	headerText += "\n" + i18n.T(locale, "stocktake.report_progress", report.Total, report.Seen)
+	headerTextBlock := slack.NewTextBlockObject("mrkdwn", headerText, false, false)
+	headerSection := slack.NewSectionBlock(headerTextBlock, nil, nil)
+	sections = append(sections, headerSection, divSection)
+
+	// Missing
+	missingText := i18n.T(locale, "stocktake.missing", len(report.Missing))
+	if len(report.Missing) > 0 {
+		missingText += "\n" + renderBookList(report.Missing, locale)
+	}
+	sections = append(sections, slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", missingText, false, false), nil, nil))
+
+	// Borrowed On Shelf
+	borrowedText := i18n.T(locale, "stocktake.borrowed_on_shelf", len(report.BorrowedOnShelf))
+	if len(report.BorrowedOnShelf) > 0 {
+		borrowedText += "\n" + renderBookList(report.BorrowedOnShelf, locale)
+	}
+	sections = append(sections, slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", borrowedText, false, false), nil, nil))
+
+	// Misplaced
+	misplacedText := i18n.T(locale, "stocktake.misplaced", len(report.Misplaced))
+	for i, misplaced := range report.Misplaced {
+		if i == stocktakeListLimit {
+			misplacedText += "\n" + i18n.T(locale, "list.more", len(report.Misplaced)-stocktakeListLimit)
+			break
+		}
+		misplacedText += "\n" + i18n.T(locale, "stocktake.misplaced_book", misplaced.Book.ID, misplaced.Book.Title, misplaced.Book.Position, misplaced.SeenPosition)
+	}
+	sections = append(sections, slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", misplacedText, false, false), nil, nil))
+
+	return slack.NewBlockMessage(sections...)
+}
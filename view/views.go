@@ -4,27 +4,52 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
 	models "github.com/harrydrippin/go-spreadsheet-library/model"
 	"github.com/harrydrippin/go-spreadsheet-library/utils"
 	"github.com/slack-go/slack"
 )
 
-func RenderSearchResult(query string, books []models.Book) slack.Message {
+func renderReviewSummary(summary models.ReviewSummary, locale string) string {
+	if summary.Count == 0 {
+		return ""
+	}
+
+	text := i18n.T(locale, "review.summary", summary.Average, summary.Count)
+	if len(summary.Recent) > 0 {
+		review := summary.Recent[0]
+		text += i18n.T(locale, "review.recent", review.Comment, review.Reviewer)
+	}
+
+	return text
+}
+
+func RenderSearchResult(query string, books []models.Book, summaries map[int]models.ReviewSummary, locale string) slack.Message {
 	spreadsheetLink := fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s", utils.NewConfig().GoogleSpreadsheetID)
 
 	divSection := slack.NewDividerBlock()
 	sections := make([]slack.Block, 0)
 
 	// Header Text
-	headerText := fmt.Sprintf("검색하신 *%s* 에 대한 *%d개* 의 결과가 있어요.", query, len(books))
+	headerText := i18n.T(locale, "search.header", query, len(books))
 	headerTextBlock := slack.NewTextBlockObject("mrkdwn", headerText, false, false)
 	headerSection := slack.NewSectionBlock(headerTextBlock, nil, nil)
 	sections = append(sections, headerSection)
 
 	if len(books) == 0 {
-		additionalText := fmt.Sprintf("조금 더 일반적인 키워드로 검색해보시거나, <%s|Spreadsheet>에서 직접 찾아주세요.", spreadsheetLink)
+		additionalText := i18n.T(locale, "search.empty", spreadsheetLink)
 		additionalTextBlock := slack.NewTextBlockObject("mrkdwn", additionalText, false, false)
-		additionalSection := slack.NewSectionBlock(additionalTextBlock, nil, nil)
+		additionalSection := slack.NewSectionBlock(
+			additionalTextBlock,
+			nil,
+			slack.NewAccessory(
+				slack.NewButtonBlockElement(
+					utils.RequestThisBook,
+					query,
+					slack.NewTextBlockObject("plain_text", i18n.T(locale, "button.request"), false, false),
+				),
+			),
+		)
 
 		sections = append(sections, additionalSection)
 		return slack.NewBlockMessage(sections...)
@@ -33,15 +58,14 @@ func RenderSearchResult(query string, books []models.Book) slack.Message {
 
 		for _, book := range books {
 			statusText := ""
-			if book.Status == models.StatusBorrowed {
-				statusText = fmt.Sprintf("*대출* (%s, %s 반납 예정)", book.Borrower, book.DueDate)
-			} else if book.Status == models.StatusOverdue {
-				statusText = fmt.Sprintf("*연체* (%s, %s 반납 예정)", book.Borrower, book.DueDate)
+			if book.Status == models.StatusBorrowed || book.Status == models.StatusOverdue {
+				statusText = i18n.T(locale, "book.status_due", book.Status, book.Borrower, book.DueDate)
 			} else {
-				statusText = "사내 비치"
+				statusText = book.Status.Label(locale)
 			}
 
-			bookInfoText := fmt.Sprintf(">*%s*\n>%s 지음, %s\n>현재 상태: %s", book.Title, book.Author, book.Publisher, statusText)
+			bookInfoText := i18n.T(locale, "book.info", book.Title, book.Author, book.Publisher, statusText)
+			bookInfoText += renderReviewSummary(summaries[book.ID], locale)
 			bookInfoBlock := slack.NewTextBlockObject("mrkdwn", bookInfoText, false, false)
 			bookInfoSection := slack.NewSectionBlock(
 				bookInfoBlock,
@@ -50,7 +74,7 @@ func RenderSearchResult(query string, books []models.Book) slack.Message {
 					slack.NewButtonBlockElement(
 						utils.BorrowThisBook,
 						strconv.Itoa(book.ID),
-						slack.NewTextBlockObject("plain_text", "대출하기", false, false),
+						slack.NewTextBlockObject("plain_text", i18n.T(locale, "button.borrow"), false, false),
 					),
 				),
 			)
@@ -59,22 +83,21 @@ func RenderSearchResult(query string, books []models.Book) slack.Message {
 
 		return slack.NewBlockMessage(sections...)
 	} else {
-		additionalText := fmt.Sprintf("검색 결과는 5개까지만 표시돼요. 그 이상은 <%s|Spreadsheet>에서 직접 찾아주세요.", spreadsheetLink)
+		additionalText := i18n.T(locale, "search.too_many", spreadsheetLink)
 		additionalTextBlock := slack.NewTextBlockObject("mrkdwn", additionalText, false, false)
 		additionalSection := slack.NewSectionBlock(additionalTextBlock, nil, nil)
 		sections = append(sections, additionalSection, divSection)
 
 		for _, book := range books[:5] {
 			statusText := ""
-			if book.Status == models.StatusBorrowed {
-				statusText = fmt.Sprintf("*대출* (%s, %s 반납 예정)", book.Borrower, book.DueDate)
-			} else if book.Status == models.StatusOverdue {
-				statusText = fmt.Sprintf("*연체* (%s, %s 반납 예정)", book.Borrower, book.DueDate)
+			if book.Status == models.StatusBorrowed || book.Status == models.StatusOverdue {
+				statusText = i18n.T(locale, "book.status_due", book.Status, book.Borrower, book.DueDate)
 			} else {
-				statusText = "사내 비치"
+				statusText = book.Status.Label(locale)
 			}
 
-			bookInfoText := fmt.Sprintf(">*%s*\n>%s 지음, %s\n>현재 상태: %s", book.Title, book.Author, book.Publisher, statusText)
+			bookInfoText := i18n.T(locale, "book.info", book.Title, book.Author, book.Publisher, statusText)
+			bookInfoText += renderReviewSummary(summaries[book.ID], locale)
 			bookInfoBlock := slack.NewTextBlockObject("mrkdwn", bookInfoText, false, false)
 			bookInfoSection := slack.NewSectionBlock(
 				bookInfoBlock,
@@ -83,7 +106,7 @@ func RenderSearchResult(query string, books []models.Book) slack.Message {
 					slack.NewButtonBlockElement(
 						utils.BorrowThisBook,
 						strconv.Itoa(book.ID),
-						slack.NewTextBlockObject("plain_text", "대출하기", false, false),
+						slack.NewTextBlockObject("plain_text", i18n.T(locale, "button.borrow"), false, false),
 					),
 				),
 			)
@@ -95,19 +118,19 @@ func RenderSearchResult(query string, books []models.Book) slack.Message {
 	}
 }
 
-func RenderBorrowResult(book models.Book) slack.Message {
+func RenderBorrowResult(book models.Book, locale string) slack.Message {
 	divSection := slack.NewDividerBlock()
 	sections := make([]slack.Block, 0)
 
 	// Header Text
-	headerText := "대출이 완료되었어요! 아래 내용을 확인해주세요.\n꼭 기한 내에 반납해주시고, 불가피하다면 연장 신청을 부탁드려요 :)"
+	headerText := i18n.T(locale, "borrow.done")
 	headerTextBlock := slack.NewTextBlockObject("mrkdwn", headerText, false, false)
 	headerSection := slack.NewSectionBlock(headerTextBlock, nil, nil)
 	sections = append(sections, headerSection, divSection)
 
 	// Book Info
-	statusText := fmt.Sprintf("*대출* (%s, %s 반납 예정)", book.Borrower, book.DueDate)
-	bookInfoText := fmt.Sprintf(">*%s*\n>%s 지음, %s\n>현재 상태: %s", book.Title, book.Author, book.Publisher, statusText)
+	statusText := i18n.T(locale, "book.status_due", book.Status, book.Borrower, book.DueDate)
+	bookInfoText := i18n.T(locale, "book.info", book.Title, book.Author, book.Publisher, statusText)
 	bookInfoBlock := slack.NewTextBlockObject("mrkdwn", bookInfoText, false, false)
 	bookInfoSection := slack.NewSectionBlock(bookInfoBlock, nil, nil)
 
@@ -116,40 +139,57 @@ func RenderBorrowResult(book models.Book) slack.Message {
 	return slack.NewBlockMessage(sections...)
 }
 
-func RenderReturnResult(book models.Book) slack.Message {
+func RenderReturnResult(book models.Book, locale string) slack.Message {
 	divSection := slack.NewDividerBlock()
 	sections := make([]slack.Block, 0)
 
 	// Header Text
-	headerText := "반납이 완료되었어요. 이용해주셔서 감사해요!"
+	headerText := i18n.T(locale, "return.done")
 	headerTextBlock := slack.NewTextBlockObject("mrkdwn", headerText, false, false)
 	headerSection := slack.NewSectionBlock(headerTextBlock, nil, nil)
 	sections = append(sections, headerSection, divSection)
 
 	// Book Info
-	statusText := "사내 비치"
-	bookInfoText := fmt.Sprintf(">*%s*\n>%s 지음, %s\n>현재 상태: %s", book.Title, book.Author, book.Publisher, statusText)
+	statusText := book.Status.Label(locale)
+	bookInfoText := i18n.T(locale, "book.info", book.Title, book.Author, book.Publisher, statusText)
 	bookInfoBlock := slack.NewTextBlockObject("mrkdwn", bookInfoText, false, false)
 	bookInfoSection := slack.NewSectionBlock(bookInfoBlock, nil, nil)
 
-	sections = append(sections, bookInfoSection)
+	sections = append(sections, bookInfoSection, divSection)
+
+	// Rating Prompt
+	rateText := i18n.T(locale, "return.rate_prompt")
+	rateTextBlock := slack.NewTextBlockObject("mrkdwn", rateText, false, false)
+	rateSection := slack.NewSectionBlock(
+		rateTextBlock,
+		nil,
+		slack.NewAccessory(
+			slack.NewButtonBlockElement(
+				utils.RateThisBook,
+				strconv.Itoa(book.ID),
+				slack.NewTextBlockObject("plain_text", i18n.T(locale, "button.rate"), false, false),
+			),
+		),
+	)
+
+	sections = append(sections, rateSection)
 
 	return slack.NewBlockMessage(sections...)
 }
 
-func RenderExtendResult(book models.Book) slack.Message {
+func RenderExtendResult(book models.Book, locale string) slack.Message {
 	divSection := slack.NewDividerBlock()
 	sections := make([]slack.Block, 0)
 
 	// Header Text
-	headerText := "연장이 완료되었어요. 갱신된 기간을 확인해주시고, 기간 내에 반납해주세요!"
+	headerText := i18n.T(locale, "extend.done")
 	headerTextBlock := slack.NewTextBlockObject("mrkdwn", headerText, false, false)
 	headerSection := slack.NewSectionBlock(headerTextBlock, nil, nil)
 	sections = append(sections, headerSection, divSection)
 
 	// Book Info
-	statusText := fmt.Sprintf("*연장 처리* (%s, %s 반납 예정)", book.Borrower, book.DueDate)
-	bookInfoText := fmt.Sprintf(">*%s*\n>%s 지음, %s\n>현재 상태: %s", book.Title, book.Author, book.Publisher, statusText)
+	statusText := i18n.T(locale, "book.status_extended", book.Borrower, book.DueDate)
+	bookInfoText := i18n.T(locale, "book.info", book.Title, book.Author, book.Publisher, statusText)
 	bookInfoBlock := slack.NewTextBlockObject("mrkdwn", bookInfoText, false, false)
 	bookInfoSection := slack.NewSectionBlock(bookInfoBlock, nil, nil)
 
@@ -158,20 +198,20 @@ func RenderExtendResult(book models.Book) slack.Message {
 	return slack.NewBlockMessage(sections...)
 }
 
-func RenderStatusResult(books []models.Book, borrower string) slack.Message {
+func RenderStatusResult(books []models.Book, borrower string, locale string) slack.Message {
 	spreadsheetLink := fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s", utils.NewConfig().GoogleSpreadsheetID)
 
 	divSection := slack.NewDividerBlock()
 	sections := make([]slack.Block, 0)
 
 	// Header Text
-	headerText := fmt.Sprintf("@%s 님께서는 %d 권의 책을 대출하셨어요.", borrower, len(books))
+	headerText := i18n.T(locale, "status.header", borrower, len(books))
 	headerTextBlock := slack.NewTextBlockObject("mrkdwn", headerText, false, false)
 	headerSection := slack.NewSectionBlock(headerTextBlock, nil, nil)
 	sections = append(sections, headerSection)
 
 	if len(books) == 0 {
-		additionalText := fmt.Sprintf("대출하시려면, `/도서관 검색 <책 이름의 일부>` 를 이용해보세요!")
+		additionalText := i18n.T(locale, "status.empty")
 		additionalTextBlock := slack.NewTextBlockObject("mrkdwn", additionalText, false, false)
 		additionalSection := slack.NewSectionBlock(additionalTextBlock, nil, nil)
 
@@ -182,26 +222,26 @@ func RenderStatusResult(books []models.Book, borrower string) slack.Message {
 
 		for _, book := range books {
 			statusText := ""
-			if book.Status == models.StatusOverdue {
-				statusText = fmt.Sprintf("*연체* (%s, %s 반납 예정)", book.Borrower, book.DueDate)
+			if book.Status == models.StatusLost {
+				statusText = i18n.T(locale, "status.lost")
 			} else {
-				statusText = fmt.Sprintf("*대출* (%s, %s 반납 예정)", book.Borrower, book.DueDate)
+				statusText = i18n.T(locale, "book.status_due", book.Status, book.Borrower, book.DueDate)
 			}
 
-			bookInfoText := fmt.Sprintf(">*%s*\n>%s 지음, %s\n>현재 상태: %s", book.Title, book.Author, book.Publisher, statusText)
+			bookInfoText := i18n.T(locale, "book.info", book.Title, book.Author, book.Publisher, statusText)
 			bookInfoBlock := slack.NewTextBlockObject("mrkdwn", bookInfoText, false, false)
 			bookInfoSection := slack.NewSectionBlock(bookInfoBlock, nil, nil)
 
 			returnButtonBlock := slack.NewButtonBlockElement(
 				utils.ReturnThisBook,
 				strconv.Itoa(book.ID),
-				slack.NewTextBlockObject("plain_text", "반납하기", false, false),
+				slack.NewTextBlockObject("plain_text", i18n.T(locale, "button.return"), false, false),
 			)
 
 			extendButtonBlock := slack.NewButtonBlockElement(
 				utils.ExtendThisBook,
 				strconv.Itoa(book.ID),
-				slack.NewTextBlockObject("plain_text", "연장하기", false, false),
+				slack.NewTextBlockObject("plain_text", i18n.T(locale, "button.extend"), false, false),
 			)
 
 			actionBlock := slack.NewActionBlock("status_action_block_"+strconv.Itoa(book.ID), returnButtonBlock, extendButtonBlock)
@@ -211,33 +251,33 @@ func RenderStatusResult(books []models.Book, borrower string) slack.Message {
 
 		return slack.NewBlockMessage(sections...)
 	} else {
-		additionalText := fmt.Sprintf("검색 결과는 5개까지만 표시돼요. 그 이상은 <%s|Spreadsheet>에서 직접 찾아주세요.", spreadsheetLink)
+		additionalText := i18n.T(locale, "search.too_many", spreadsheetLink)
 		additionalTextBlock := slack.NewTextBlockObject("mrkdwn", additionalText, false, false)
 		additionalSection := slack.NewSectionBlock(additionalTextBlock, nil, nil)
 		sections = append(sections, additionalSection, divSection)
 
 		for _, book := range books[:5] {
 			statusText := ""
-			if book.Status == models.StatusOverdue {
-				statusText = fmt.Sprintf("*연체* (%s, %s 반납 예정)", book.Borrower, book.DueDate)
+			if book.Status == models.StatusLost {
+				statusText = i18n.T(locale, "status.lost")
 			} else {
-				statusText = fmt.Sprintf("*대출* (%s, %s 반납 예정)", book.Borrower, book.DueDate)
+				statusText = i18n.T(locale, "book.status_due", book.Status, book.Borrower, book.DueDate)
 			}
 
-			bookInfoText := fmt.Sprintf(">*%s*\n>%s 지음, %s\n>현재 상태: %s", book.Title, book.Author, book.Publisher, statusText)
+			bookInfoText := i18n.T(locale, "book.info", book.Title, book.Author, book.Publisher, statusText)
 			bookInfoBlock := slack.NewTextBlockObject("mrkdwn", bookInfoText, false, false)
 			bookInfoSection := slack.NewSectionBlock(bookInfoBlock, nil, nil)
 
 			returnButtonBlock := slack.NewButtonBlockElement(
 				utils.ReturnThisBook,
 				strconv.Itoa(book.ID),
-				slack.NewTextBlockObject("plain_text", "반납하기", false, false),
+				slack.NewTextBlockObject("plain_text", i18n.T(locale, "button.return"), false, false),
 			)
 
 			extendButtonBlock := slack.NewButtonBlockElement(
 				utils.ExtendThisBook,
 				strconv.Itoa(book.ID),
-				slack.NewTextBlockObject("plain_text", "연장하기", false, false),
+				slack.NewTextBlockObject("plain_text", i18n.T(locale, "button.extend"), false, false),
 			)
 
 			actionBlock := slack.NewActionBlock("status_action_block", returnButtonBlock, extendButtonBlock)
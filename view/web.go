@@ -0,0 +1,96 @@
+package view
+
+import (
+	"embed"
+	"html/template"
+	"io"
+
+	"github.com/harrydrippin/go-spreadsheet-library/i18n"
+	models "github.com/harrydrippin/go-spreadsheet-library/model"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{"t": i18n.T}).ParseFS(templateFS, "templates/*.html"))
+
+// Label is a single printable label on the label sheet.
+type Label struct {
+	Book   models.Book
+	URL    string
+	QRCode template.HTML
+}
+
+// LabelSheet is the printable sheet of QR code labels.
+type LabelSheet struct {
+	Locale string
+	Labels []Label
+}
+
+// ScanPage holds everything shown on the page opened by scanning a book's QR code.
+// User is the signed-in user, and is empty if no one has signed in on the device.
+type ScanPage struct {
+	Locale    string
+	Path      string
+	Book      models.Book
+	User      string
+	CSRF      string
+	Message   string
+	Error     string
+	Stocktake bool
+}
+
+// StocktakePage holds everything shown on the mobile stocktake page.
+type StocktakePage struct {
+	Locale   string
+	Session  models.StocktakeSession
+	Report   models.StocktakeReport
+	User     string
+	CSRF     string
+	Position string
+	Message  string
+	Error    string
+}
+
+// LoginPage is the page for signing in on the mobile pages with an API token, going back to Next afterwards.
+type LoginPage struct {
+	Locale string
+	Next   string
+	CSRF   string
+	Error  string
+}
+
+// APIDocsPage is the page rendering the OpenAPI document of the API.
+type APIDocsPage struct {
+	SpecURL string
+}
+
+// CanBorrow reports whether the scanned book can be borrowed right now.
+func (p ScanPage) CanBorrow() bool {
+	return p.Book.Status == models.StatusInOffice
+}
+
+// RenderLabelSheet writes a printable HTML sheet of QR code labels.
+func RenderLabelSheet(w io.Writer, sheet LabelSheet) error {
+	return templates.ExecuteTemplate(w, "labels.html", sheet)
+}
+
+// RenderScanPage writes the mobile page for borrowing or returning a scanned book.
+func RenderScanPage(w io.Writer, page ScanPage) error {
+	return templates.ExecuteTemplate(w, "scan.html", page)
+}
+
+// RenderStocktakePage writes the mobile page for marking books as seen during a stocktake.
+func RenderStocktakePage(w io.Writer, page StocktakePage) error {
+	return templates.ExecuteTemplate(w, "stocktake.html", page)
+}
+
+// RenderLoginPage writes the page for signing in on the mobile pages.
+func RenderLoginPage(w io.Writer, page LoginPage) error {
+	return templates.ExecuteTemplate(w, "login.html", page)
+}
+
+// RenderAPIDocsPage writes the page rendering the OpenAPI document of the API.
+func RenderAPIDocsPage(w io.Writer, page APIDocsPage) error {
+	return templates.ExecuteTemplate(w, "docs.html", page)
+}